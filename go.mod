@@ -15,3 +15,6 @@ require (
 	google.golang.org/genproto v0.0.0-20190502173448-54afdca5d873 // indirect
 	google.golang.org/grpc v1.20.1 // indirect
 )
+
+// go-mongodbatlas with the Atlas APIs the provider needs which aren't released upstream yet
+replace github.com/akshaykarle/go-mongodbatlas => ./third_party/go-mongodbatlas
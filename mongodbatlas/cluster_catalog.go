@@ -0,0 +1,260 @@
+package mongodbatlas
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// clusterCatalog lists the providers, regions, instance sizes and MongoDB versions
+// Atlas supports, to reject invalid clusters at plan time instead of during apply.
+type clusterCatalog struct {
+	Providers       map[string]*catalogProvider `json:"providers"`
+	MongoDBVersions []string                    `json:"mongodb_versions"`
+}
+
+type catalogProvider struct {
+	Regions       []string                       `json:"regions"`
+	InstanceSizes map[string]catalogInstanceSize `json:"instance_sizes"`
+}
+
+type catalogInstanceSize struct {
+	MaxDiskSizeGB float64 `json:"max_disk_size_gb"`
+}
+
+// defaultClusterCatalogJSON is the catalog built into the provider. Its regions, instance
+// sizes and versions can be changed without a release with the cluster_catalog_file argument.
+const defaultClusterCatalogJSON = `{
+  "mongodb_versions": ["3.4", "3.6", "4.0", "4.2", "4.4"],
+  "providers": {
+    "AWS": {
+      "regions": [
+        "US_EAST_1", "US_EAST_2", "US_WEST_1", "US_WEST_2", "CA_CENTRAL_1", "SA_EAST_1",
+        "EU_NORTH_1", "EU_WEST_1", "EU_WEST_2", "EU_WEST_3", "EU_CENTRAL_1", "ME_SOUTH_1",
+        "AP_EAST_1", "AP_NORTHEAST_1", "AP_NORTHEAST_2", "AP_SOUTH_1", "AP_SOUTHEAST_1", "AP_SOUTHEAST_2"
+      ],
+      "instance_sizes": {
+        "M10": {"max_disk_size_gb": 128},
+        "M20": {"max_disk_size_gb": 256},
+        "M30": {"max_disk_size_gb": 512},
+        "M40": {"max_disk_size_gb": 1024},
+        "M50": {"max_disk_size_gb": 4096},
+        "M60": {"max_disk_size_gb": 4096},
+        "M80": {"max_disk_size_gb": 4096},
+        "M100": {"max_disk_size_gb": 4096},
+        "M140": {"max_disk_size_gb": 4096},
+        "M200": {"max_disk_size_gb": 4096},
+        "M300": {"max_disk_size_gb": 4096},
+        "R40": {"max_disk_size_gb": 1024},
+        "R50": {"max_disk_size_gb": 4096},
+        "R60": {"max_disk_size_gb": 4096},
+        "R80": {"max_disk_size_gb": 4096},
+        "R200": {"max_disk_size_gb": 4096},
+        "R300": {"max_disk_size_gb": 4096},
+        "R400": {"max_disk_size_gb": 4096},
+        "R700": {"max_disk_size_gb": 4096}
+      }
+    },
+    "GCP": {
+      "regions": [
+        "EASTERN_US", "CENTRAL_US", "WESTERN_US", "US_WEST_2", "US_WEST_3", "NORTH_AMERICA_NORTHEAST_1",
+        "SOUTH_AMERICA_EAST_1", "WESTERN_EUROPE", "EUROPE_NORTH_1", "EUROPE_WEST_2", "EUROPE_WEST_3",
+        "EUROPE_WEST_4", "EUROPE_WEST_6", "EASTERN_ASIA_PACIFIC", "NORTHEASTERN_ASIA_PACIFIC",
+        "SOUTHEASTERN_ASIA_PACIFIC", "ASIA_EAST_2", "ASIA_NORTHEAST_2", "ASIA_SOUTH_1", "AUSTRALIA_SOUTHEAST_1"
+      ],
+      "instance_sizes": {
+        "M10": {"max_disk_size_gb": 128},
+        "M20": {"max_disk_size_gb": 256},
+        "M30": {"max_disk_size_gb": 512},
+        "M40": {"max_disk_size_gb": 1024},
+        "M50": {"max_disk_size_gb": 4096},
+        "M60": {"max_disk_size_gb": 4096},
+        "M80": {"max_disk_size_gb": 4096},
+        "M140": {"max_disk_size_gb": 4096},
+        "M200": {"max_disk_size_gb": 4096},
+        "M250": {"max_disk_size_gb": 4096},
+        "M300": {"max_disk_size_gb": 4096},
+        "M400": {"max_disk_size_gb": 4096},
+        "R40": {"max_disk_size_gb": 1024},
+        "R50": {"max_disk_size_gb": 4096},
+        "R60": {"max_disk_size_gb": 4096},
+        "R80": {"max_disk_size_gb": 4096},
+        "R200": {"max_disk_size_gb": 4096},
+        "R300": {"max_disk_size_gb": 4096},
+        "R400": {"max_disk_size_gb": 4096}
+      }
+    },
+    "AZURE": {
+      "regions": [
+        "US_EAST", "US_EAST_2", "US_CENTRAL", "US_NORTH_CENTRAL", "US_WEST", "US_WEST_2", "US_SOUTH_CENTRAL",
+        "CANADA_EAST", "CANADA_CENTRAL", "BRAZIL_SOUTH", "EUROPE_NORTH", "EUROPE_WEST", "UK_SOUTH", "UK_WEST",
+        "FRANCE_CENTRAL", "GERMANY_WEST_CENTRAL", "SWITZERLAND_NORTH", "ASIA_EAST", "ASIA_SOUTH_EAST",
+        "AUSTRALIA_EAST", "AUSTRALIA_SOUTH_EAST", "INDIA_CENTRAL", "INDIA_SOUTH", "INDIA_WEST", "JAPAN_EAST",
+        "JAPAN_WEST", "KOREA_CENTRAL", "KOREA_SOUTH", "SOUTH_AFRICA_NORTH", "UAE_NORTH"
+      ],
+      "instance_sizes": {
+        "M10": {"max_disk_size_gb": 128},
+        "M20": {"max_disk_size_gb": 256},
+        "M30": {"max_disk_size_gb": 512},
+        "M40": {"max_disk_size_gb": 1024},
+        "M50": {"max_disk_size_gb": 4096},
+        "M60": {"max_disk_size_gb": 4096},
+        "M80": {"max_disk_size_gb": 4096},
+        "M200": {"max_disk_size_gb": 4096},
+        "R40": {"max_disk_size_gb": 1024},
+        "R50": {"max_disk_size_gb": 4096},
+        "R60": {"max_disk_size_gb": 4096},
+        "R80": {"max_disk_size_gb": 4096},
+        "R200": {"max_disk_size_gb": 4096}
+      }
+    },
+    "TENANT": {
+      "regions": [],
+      "instance_sizes": {
+        "M0": {"max_disk_size_gb": 0.5},
+        "M2": {"max_disk_size_gb": 2},
+        "M5": {"max_disk_size_gb": 5}
+      }
+    }
+  }
+}`
+
+func defaultClusterCatalog() *clusterCatalog {
+	catalog := &clusterCatalog{}
+	if err := json.Unmarshal([]byte(defaultClusterCatalogJSON), catalog); err != nil {
+		panic(fmt.Sprintf("invalid built-in cluster catalog: %s", err))
+	}
+	return catalog
+}
+
+// loadClusterCatalog returns the built-in catalog, overridden by the providers,
+// regions, instance sizes and versions of the catalog file at path, if any.
+func loadClusterCatalog(path string) (*clusterCatalog, error) {
+	catalog := defaultClusterCatalog()
+	if path == "" {
+		return catalog, nil
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading cluster catalog file %s: %s", path, err)
+	}
+	override := &clusterCatalog{}
+	if err := json.Unmarshal(contents, override); err != nil {
+		return nil, fmt.Errorf("Error parsing cluster catalog file %s: %s", path, err)
+	}
+
+	catalog.merge(override)
+	return catalog, nil
+}
+
+// merge gives the entries of other precedence over the ones of the catalog. The regions
+// and versions of other replace the catalog's when they're set, and its providers and
+// instance sizes replace the ones of the catalog with the same name.
+func (c *clusterCatalog) merge(other *clusterCatalog) {
+	if other.MongoDBVersions != nil {
+		c.MongoDBVersions = other.MongoDBVersions
+	}
+
+	for name, p := range other.Providers {
+		if p == nil {
+			continue
+		}
+		existing, ok := c.Providers[name]
+		if !ok {
+			c.Providers[name] = p
+			continue
+		}
+		if p.Regions != nil {
+			existing.Regions = p.Regions
+		}
+		if existing.InstanceSizes == nil {
+			existing.InstanceSizes = map[string]catalogInstanceSize{}
+		}
+		for size, s := range p.InstanceSizes {
+			existing.InstanceSizes[size] = s
+		}
+	}
+}
+
+func (c *clusterCatalog) validateProvider(providerName string) (*catalogProvider, error) {
+	p, ok := c.Providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("provider %s is not in the cluster catalog, valid providers are %s", providerName, strings.Join(c.providerNames(), ", "))
+	}
+	return p, nil
+}
+
+func (c *clusterCatalog) validateInstanceSize(providerName, size string) error {
+	p, err := c.validateProvider(providerName)
+	if err != nil {
+		return err
+	}
+	if _, ok := p.InstanceSizes[size]; !ok {
+		return fmt.Errorf("instance size %s is not available on %s, valid sizes are %s", size, providerName, strings.Join(p.instanceSizeNames(), ", "))
+	}
+	return nil
+}
+
+func (c *clusterCatalog) validateRegion(providerName, region string) error {
+	p, err := c.validateProvider(providerName)
+	if err != nil {
+		return err
+	}
+	for _, r := range p.Regions {
+		if r == region {
+			return nil
+		}
+	}
+	return fmt.Errorf("region %s is not a %s region, add it with the provider's cluster_catalog_file if it is new", region, providerName)
+}
+
+func (c *clusterCatalog) validateDiskSize(providerName, size string, diskSizeGB float64) error {
+	p, err := c.validateProvider(providerName)
+	if err != nil {
+		return err
+	}
+	s, ok := p.InstanceSizes[size]
+	if !ok || s.MaxDiskSizeGB == 0 {
+		return nil
+	}
+	if diskSizeGB > s.MaxDiskSizeGB {
+		return fmt.Errorf("disk_size_gb can be at most %v for a %s cluster on %s, got %v", s.MaxDiskSizeGB, size, providerName, diskSizeGB)
+	}
+	return nil
+}
+
+func (c *clusterCatalog) validateMongoDBVersion(version string) error {
+	for _, v := range c.MongoDBVersions {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("mongodb_major_version %s is not supported, valid versions are %s", version, strings.Join(c.MongoDBVersions, ", "))
+}
+
+func (c *clusterCatalog) providerNames() []string {
+	names := make([]string, 0, len(c.Providers))
+	for name := range c.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// instanceSizeNames sorts the sizes by class, then by size, e.g. M10, M20, M100, R40
+func (p *catalogProvider) instanceSizeNames() []string {
+	names := make([]string, 0, len(p.InstanceSizes))
+	for name := range p.InstanceSizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i][0] != names[j][0] {
+			return names[i][0] < names[j][0]
+		}
+		return instanceSizeNumber(names[i]) < instanceSizeNumber(names[j])
+	})
+	return names
+}
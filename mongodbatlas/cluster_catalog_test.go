@@ -0,0 +1,94 @@
+package mongodbatlas
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestClusterCatalog_validate(t *testing.T) {
+	catalog := defaultClusterCatalog()
+
+	cases := []struct {
+		name  string
+		err   error
+		valid bool
+	}{
+		{"AWS M10", catalog.validateInstanceSize("AWS", "M10"), true},
+		{"AWS M2", catalog.validateInstanceSize("AWS", "M2"), false},
+		{"TENANT M5", catalog.validateInstanceSize("TENANT", "M5"), true},
+		{"unknown provider", catalog.validateInstanceSize("OCI", "M10"), false},
+		{"AWS US_EAST_1", catalog.validateRegion("AWS", "US_EAST_1"), true},
+		{"AWS CENTRAL_US", catalog.validateRegion("AWS", "CENTRAL_US"), false},
+		{"GCP CENTRAL_US", catalog.validateRegion("GCP", "CENTRAL_US"), true},
+		{"AWS M10 128GB", catalog.validateDiskSize("AWS", "M10", 128), true},
+		{"AWS M10 200GB", catalog.validateDiskSize("AWS", "M10", 200), false},
+		{"version 4.0", catalog.validateMongoDBVersion("4.0"), true},
+		{"version 2.6", catalog.validateMongoDBVersion("2.6"), false},
+	}
+
+	for _, c := range cases {
+		if c.valid && c.err != nil {
+			t.Errorf("%s: expected no error, got %s", c.name, c.err)
+		}
+		if !c.valid && c.err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+}
+
+func TestClusterCatalog_loadFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "cluster-catalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{
+  "mongodb_versions": ["5.0"],
+  "providers": {
+    "AWS": {
+      "regions": ["US_EAST_1", "AP_SOUTHEAST_3"],
+      "instance_sizes": {"M10": {"max_disk_size_gb": 256}}
+    }
+  }
+}`)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := loadClusterCatalog(f.Name())
+	if err != nil {
+		t.Fatalf("error loading catalog: %s", err)
+	}
+
+	if err := catalog.validateRegion("AWS", "AP_SOUTHEAST_3"); err != nil {
+		t.Errorf("expected the new region to be valid, got %s", err)
+	}
+	if err := catalog.validateRegion("AWS", "US_EAST_1"); err != nil {
+		t.Errorf("expected the listed built-in region to be valid, got %s", err)
+	}
+	if err := catalog.validateRegion("AWS", "US_WEST_2"); err == nil {
+		t.Error("expected the regions of the file to replace the built-in ones")
+	}
+	if err := catalog.validateRegion("GCP", "CENTRAL_US"); err != nil {
+		t.Errorf("expected the regions of other providers to be kept, got %s", err)
+	}
+	if err := catalog.validateDiskSize("AWS", "M10", 200); err != nil {
+		t.Errorf("expected the instance size to be replaced, got %s", err)
+	}
+	if err := catalog.validateInstanceSize("AWS", "M20"); err != nil {
+		t.Errorf("expected the built-in instance sizes to be kept, got %s", err)
+	}
+	if err := catalog.validateMongoDBVersion("5.0"); err != nil {
+		t.Errorf("expected the new version to be valid, got %s", err)
+	}
+	if err := catalog.validateMongoDBVersion("4.4"); err == nil {
+		t.Error("expected the versions of the file to replace the built-in ones")
+	}
+
+	if _, err := loadClusterCatalog(f.Name() + ".missing"); err == nil {
+		t.Error("expected an error for a missing catalog file")
+	}
+}
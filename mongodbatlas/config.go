@@ -10,11 +10,35 @@ import (
 type Config struct {
 	AtlasUsername string
 	AtlasAPIKey   string
+	DefaultLabels map[string]string
+	// ClusterCatalogFile overrides entries of the built-in cluster catalog
+	ClusterCatalogFile string
 }
 
-func (c *Config) NewClient() *ma.Client {
+// MongoDBClient is the Atlas client along with the provider level settings shared by all resources.
+type MongoDBClient struct {
+	Client         *ma.Client
+	DefaultLabels  map[string]string
+	ClusterCatalog *clusterCatalog
+}
+
+// isNotFound tells whether an Atlas API response reports the requested object as missing,
+// e.g. after it was deleted outside of Terraform. resp is nil when Atlas wasn't reached.
+func isNotFound(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+func (c *Config) NewClient() (*MongoDBClient, error) {
 	t := dac.NewTransport(c.AtlasUsername, c.AtlasAPIKey)
 	httpClient := &http.Client{Transport: &t}
 	client := ma.NewClient(httpClient)
-	return client
+	catalog, err := loadClusterCatalog(c.ClusterCatalogFile)
+	if err != nil {
+		return nil, err
+	}
+	return &MongoDBClient{
+		Client:         client,
+		DefaultLabels:  c.DefaultLabels,
+		ClusterCatalog: catalog,
+	}, nil
 }
@@ -3,7 +3,6 @@ package mongodbatlas
 import (
 	"fmt"
 
-	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -50,6 +49,16 @@ func dataSourceContainer() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"azure_subscription_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"vnet_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 			"provisioned": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -64,7 +73,7 @@ func dataSourceContainer() *schema.Resource {
 }
 
 func dataSourceContainerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	id := d.Get("identifier").(string)
 	group := d.Get("group").(string)
 
@@ -103,6 +112,17 @@ func dataSourceContainerRead(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("error setting network_name for resource %s: %s", d.Id(), err)
 		}
 	}
+	if d.Get("provider_name").(string) == "AZURE" {
+		if err := d.Set("region", c.Region); err != nil {
+			return fmt.Errorf("error setting region for resource %s: %s", d.Id(), err)
+		}
+		if err := d.Set("azure_subscription_id", c.AzureSubscriptionID); err != nil {
+			return fmt.Errorf("error setting azure_subscription_id for resource %s: %s", d.Id(), err)
+		}
+		if err := d.Set("vnet_name", c.VnetName); err != nil {
+			return fmt.Errorf("error setting vnet_name for resource %s: %s", d.Id(), err)
+		}
+	}
 
 	return nil
 }
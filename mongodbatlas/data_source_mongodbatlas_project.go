@@ -3,7 +3,6 @@ package mongodbatlas
 import (
 	"fmt"
 
-	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -33,7 +32,7 @@ func dataSourceProject() *schema.Resource {
 }
 
 func dataSourceProjectRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	name := d.Get("name").(string)
 	p, _, err := client.Projects.GetByName(name)
 	if err != nil {
@@ -20,6 +20,18 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_API_KEY", ""),
 				Description: "MongoDB Atlas API Key",
 			},
+			"default_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Labels to add to every MongoDB Atlas cluster",
+			},
+			"cluster_catalog_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_CLUSTER_CATALOG_FILE", ""),
+				Description: "JSON file overriding the providers, regions, instance sizes and MongoDB versions of the built-in cluster catalog",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -28,13 +40,18 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"mongodbatlas_project":                resourceProject(),
-			"mongodbatlas_cluster":                resourceCluster(),
-			"mongodbatlas_container":              resourceContainer(),
-			"mongodbatlas_vpc_peering_connection": resourceVpcPeeringConnection(),
-			"mongodbatlas_ip_whitelist":           resourceIPWhitelist(),
-			"mongodbatlas_database_user":          resourceDatabaseUser(),
-			"mongodbatlas_alert_configuration":    resourceAlertConfiguration(),
+			"mongodbatlas_project":                   resourceProject(),
+			"mongodbatlas_cluster":                   resourceCluster(),
+			"mongodbatlas_advanced_cluster":          resourceAdvancedCluster(),
+			"mongodbatlas_cluster_failover_test":     resourceClusterFailoverTest(),
+			"mongodbatlas_cluster_outage_simulation": resourceClusterOutageSimulation(),
+			"mongodbatlas_container":                 resourceContainer(),
+			"mongodbatlas_vpc_peering_connection":    resourceVpcPeeringConnection(),
+			"mongodbatlas_ip_whitelist":              resourceIPWhitelist(),
+			"mongodbatlas_project_ip_access_list":    resourceProjectIPAccessList(),
+			"mongodbatlas_database_user":             resourceDatabaseUser(),
+			"mongodbatlas_alert_configuration":       resourceAlertConfiguration(),
+			"mongodbatlas_global_cluster_config":     resourceGlobalClusterConfig(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -43,11 +60,14 @@ func Provider() terraform.ResourceProvider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		AtlasUsername: d.Get("username").(string),
-		AtlasAPIKey:   d.Get("api_key").(string),
+		AtlasUsername:      d.Get("username").(string),
+		AtlasAPIKey:        d.Get("api_key").(string),
+		DefaultLabels:      map[string]string{},
+		ClusterCatalogFile: d.Get("cluster_catalog_file").(string),
+	}
+	for k, v := range d.Get("default_labels").(map[string]interface{}) {
+		config.DefaultLabels[k] = v.(string)
 	}
 
-	client := config.NewClient()
-
-	return client, nil
+	return config.NewClient()
 }
@@ -1,9 +1,14 @@
 package mongodbatlas
 
 import (
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -32,3 +37,66 @@ func testAccPreCheck(t *testing.T) {
 		}
 	}
 }
+
+// testRoundTripper answers the requests of an offline client.
+type testRoundTripper func(*http.Request) *http.Response
+
+func (f testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// testOfflineClient returns provider meta whose client gets the given status and body
+// for every request, to test resources without reaching Atlas.
+func testOfflineClient(status int, body string) *MongoDBClient {
+	httpClient := &http.Client{Transport: testRoundTripper(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}
+	})}
+	return &MongoDBClient{
+		Client:         ma.NewClient(httpClient),
+		ClusterCatalog: defaultClusterCatalog(),
+	}
+}
+
+// testReadNotFound checks that a Read function removes the resource from the state when
+// Atlas answers 404, and still fails on other errors.
+func testReadNotFound(t *testing.T, r *schema.Resource, raw map[string]interface{}, id string) {
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId(id)
+	notFound := testOfflineClient(http.StatusNotFound, `{"detail":"Not found.","error":404,"errorCode":"RESOURCE_NOT_FOUND","reason":"Not Found"}`)
+	if err := r.Read(d, notFound); err != nil {
+		t.Fatalf("expected no error when the resource is not found, got %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the resource to be removed from the state, got ID %q", d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId(id)
+	serverError := testOfflineClient(http.StatusInternalServerError, `{"detail":"Unexpected error.","error":500,"errorCode":"UNEXPECTED_ERROR","reason":"Internal Server Error"}`)
+	if err := r.Read(d, serverError); err == nil {
+		t.Fatal("expected an error when Atlas fails")
+	}
+	if d.Id() != id {
+		t.Fatalf("expected the resource to be kept in the state, got ID %q", d.Id())
+	}
+}
+
+// testResourceDiff plans the raw configuration against a resource with the given state
+// attributes, or a new resource if id is empty, running its CustomizeDiff.
+func testResourceDiff(t *testing.T, r *schema.Resource, id string, attributes map[string]string, raw map[string]interface{}, meta interface{}) (*terraform.InstanceDiff, error) {
+	rc, err := config.NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var state *terraform.InstanceState
+	if id != "" {
+		state = &terraform.InstanceState{ID: id, Attributes: attributes}
+	}
+	return r.Diff(state, terraform.NewResourceConfig(rc), meta)
+}
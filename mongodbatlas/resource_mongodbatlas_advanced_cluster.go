@@ -0,0 +1,509 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAdvancedCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAdvancedClusterCreate,
+		Read:   resourceAdvancedClusterRead,
+		Update: resourceAdvancedClusterUpdate,
+		Delete: resourceAdvancedClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAdvancedClusterImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+			Update: schema.DefaultTimeout(40 * time.Minute),
+			Delete: schema.DefaultTimeout(40 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cluster_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"REPLICASET", "SHARDED", "GEOSHARDED"}, false),
+			},
+			"mongodb_major_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"backup": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"disk_size_gb": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+			},
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"replication_specs": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"num_shards": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+						"zone_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"region_configs": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"provider_name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"AWS", "GCP", "AZURE", "TENANT"}, false),
+									},
+									"backing_provider_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"region_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"priority": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 7),
+									},
+									"electable_specs": advancedClusterHardwareSpecSchema(),
+									"read_only_specs": advancedClusterHardwareSpecSchema(),
+									"analytics_specs": advancedClusterHardwareSpecSchema(),
+								},
+							},
+						},
+					},
+				},
+			},
+			"identifier": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mongodb_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func advancedClusterHardwareSpecSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"instance_size": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"node_count": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+			},
+		},
+	}
+}
+
+func resourceAdvancedClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+
+	backup := d.Get("backup").(bool)
+	params := ma.AdvancedCluster{
+		Name:                d.Get("name").(string),
+		ClusterType:         d.Get("cluster_type").(string),
+		MongoDBMajorVersion: d.Get("mongodb_major_version").(string),
+		DiskSizeGB:          d.Get("disk_size_gb").(float64),
+		BackupEnabled:       &backup,
+		ReplicationSpecs:    readAdvancedReplicationSpecsFromSchema(d.Get("replication_specs").([]interface{})),
+	}
+
+	cluster, _, err := client.AdvancedClusters.Create(d.Get("group").(string), &params)
+	if err != nil {
+		return fmt.Errorf("Error creating MongoDB Advanced Cluster: %s", err)
+	}
+	d.SetId(cluster.ID)
+	log.Printf("[INFO] MongoDB Advanced Cluster ID: %s", d.Id())
+
+	log.Println("[INFO] Waiting for MongoDB Advanced Cluster to be available")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
+		Target:     []string{"IDLE"},
+		Refresh:    resourceAdvancedClusterStateRefreshFunc(d.Get("name").(string), d.Get("group").(string), client),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	// Atlas can't create a paused cluster, so pause it once it's available
+	if d.Get("paused").(bool) {
+		paused := true
+		_, _, err := client.AdvancedClusters.Update(d.Get("group").(string), d.Get("name").(string), &ma.AdvancedCluster{Paused: &paused})
+		if err != nil {
+			return fmt.Errorf("Error pausing MongoDB Advanced Cluster %s: %s", d.Get("name").(string), err)
+		}
+
+		log.Println("[INFO] Waiting for MongoDB Advanced Cluster to be paused")
+
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceAdvancedClusterRead(d, meta)
+}
+
+func resourceAdvancedClusterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+
+	c, resp, err := client.AdvancedClusters.Get(d.Get("group").(string), d.Get("name").(string))
+	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Advanced Cluster %s not found, removing from state", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading MongoDB Advanced Cluster %s: %s", d.Get("name").(string), err)
+	}
+
+	if err := d.Set("replication_specs", flattenAdvancedReplicationSpecs(c.ReplicationSpecs, d.Get("replication_specs").([]interface{}))); err != nil {
+		log.Printf("[WARN] Error setting replication_specs for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("name", c.Name); err != nil {
+		log.Printf("[WARN] Error setting name for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("group", c.GroupID); err != nil {
+		log.Printf("[WARN] Error setting group for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("cluster_type", c.ClusterType); err != nil {
+		log.Printf("[WARN] Error setting cluster_type for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("mongodb_major_version", c.MongoDBMajorVersion); err != nil {
+		log.Printf("[WARN] Error setting mongodb_major_version for (%s): %s", d.Get("name"), err)
+	}
+	if c.BackupEnabled != nil {
+		if err := d.Set("backup", *c.BackupEnabled); err != nil {
+			log.Printf("[WARN] Error setting backup for (%s): %s", d.Get("name"), err)
+		}
+	}
+	if c.Paused != nil {
+		if err := d.Set("paused", *c.Paused); err != nil {
+			log.Printf("[WARN] Error setting paused for (%s): %s", d.Get("name"), err)
+		}
+	}
+	if err := d.Set("disk_size_gb", c.DiskSizeGB); err != nil {
+		log.Printf("[WARN] Error setting disk_size_gb for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("identifier", c.ID); err != nil {
+		log.Printf("[WARN] Error setting identifier for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("state", c.StateName); err != nil {
+		log.Printf("[WARN] Error setting state for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("mongodb_version", c.MongoDBVersion); err != nil {
+		log.Printf("[WARN] Error setting mongodb_version for (%s): %s", d.Get("name"), err)
+	}
+
+	return nil
+}
+
+func resourceAdvancedClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	requestUpdate := false
+
+	// Only send the changed fields, the API keeps the others as they are
+	params := ma.AdvancedCluster{}
+
+	if d.HasChange("cluster_type") {
+		params.ClusterType = d.Get("cluster_type").(string)
+		requestUpdate = true
+	}
+	if d.HasChange("mongodb_major_version") {
+		params.MongoDBMajorVersion = d.Get("mongodb_major_version").(string)
+		requestUpdate = true
+	}
+	if d.HasChange("backup") {
+		backup := d.Get("backup").(bool)
+		params.BackupEnabled = &backup
+		requestUpdate = true
+	}
+	if d.HasChange("disk_size_gb") {
+		params.DiskSizeGB = d.Get("disk_size_gb").(float64)
+		requestUpdate = true
+	}
+	if d.HasChange("paused") {
+		paused := d.Get("paused").(bool)
+		params.Paused = &paused
+		requestUpdate = true
+	}
+	if d.HasChange("replication_specs") {
+		params.ReplicationSpecs = readAdvancedReplicationSpecsFromSchema(d.Get("replication_specs").([]interface{}))
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		_, _, err := client.AdvancedClusters.Update(d.Get("group").(string), d.Get("name").(string), &params)
+		if err != nil {
+			return fmt.Errorf("Error updating MongoDB Advanced Cluster %s: %s", d.Get("name").(string), err)
+		}
+
+		log.Println("[INFO] Waiting for MongoDB Advanced Cluster to be updated")
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
+			Target:     []string{"IDLE"},
+			Refresh:    resourceAdvancedClusterStateRefreshFunc(d.Get("name").(string), d.Get("group").(string), client),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			MinTimeout: 10 * time.Second,
+			Delay:      30 * time.Second, // Wait 30 secs before starting
+		}
+
+		// Wait, catching any errors
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceAdvancedClusterRead(d, meta)
+}
+
+func resourceAdvancedClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+
+	log.Printf("[DEBUG] MongoDB Advanced Cluster destroy: %v", d.Id())
+	_, err := client.AdvancedClusters.Delete(d.Get("group").(string), d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Error destroying MongoDB Advanced Cluster %s: %s", d.Get("name").(string), err)
+	}
+
+	log.Println("[INFO] Waiting for MongoDB Advanced Cluster to be destroyed")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"IDLE", "CREATING", "UPDATING", "REPAIRING", "DELETING"},
+		Target:     []string{"DELETED"},
+		Refresh:    resourceAdvancedClusterStateRefreshFunc(d.Get("name").(string), d.Get("group").(string), client),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAdvancedClusterImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*MongoDBClient).Client
+
+	parts := strings.SplitN(d.Id(), "-", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("To import an advanced cluster, use the format {group id}-{cluster name}")
+	}
+	gid := parts[0]
+	name := parts[1]
+
+	c, _, err := client.AdvancedClusters.Get(gid, name)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't import advanced cluster %s in group %s, error: %s", name, gid, err.Error())
+	}
+
+	d.SetId(c.ID)
+	if err := d.Set("name", c.Name); err != nil {
+		log.Printf("[WARN] Error setting name for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("group", c.GroupID); err != nil {
+		log.Printf("[WARN] Error setting group for (%s): %s", d.Get("name"), err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAdvancedClusterStateRefreshFunc(name, group string, client *ma.Client) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		c, resp, err := client.AdvancedClusters.Get(group, name)
+		if err != nil {
+			if isNotFound(resp) {
+				return 42, "DELETED", nil
+			}
+			log.Printf("Error reading MongoDB Advanced Cluster %s: %s", name, err)
+			return nil, "", err
+		}
+
+		if c.StateName != "" {
+			log.Printf("[DEBUG] MongoDB Advanced Cluster status for cluster: %s: %s", name, c.StateName)
+		}
+
+		return c, c.StateName, nil
+	}
+}
+
+func readAdvancedReplicationSpecsFromSchema(replicationSpecs []interface{}) []ma.AdvancedReplicationSpec {
+	specs := make([]ma.AdvancedReplicationSpec, len(replicationSpecs))
+	for i, r := range replicationSpecs {
+		replicationSpec := r.(map[string]interface{})
+
+		regionConfigs := replicationSpec["region_configs"].([]interface{})
+		configs := make([]ma.RegionConfig, len(regionConfigs))
+		for j, rc := range regionConfigs {
+			regionConfig := rc.(map[string]interface{})
+			priority := regionConfig["priority"].(int)
+			configs[j] = ma.RegionConfig{
+				ProviderName:        regionConfig["provider_name"].(string),
+				BackingProviderName: regionConfig["backing_provider_name"].(string),
+				RegionName:          regionConfig["region_name"].(string),
+				Priority:            &priority,
+				ElectableSpecs:      readHardwareSpecFromSchema(regionConfig["electable_specs"].([]interface{})),
+				ReadOnlySpecs:       readHardwareSpecFromSchema(regionConfig["read_only_specs"].([]interface{})),
+				AnalyticsSpecs:      readHardwareSpecFromSchema(regionConfig["analytics_specs"].([]interface{})),
+			}
+		}
+
+		specs[i] = ma.AdvancedReplicationSpec{
+			ID:            replicationSpec["id"].(string),
+			NumShards:     replicationSpec["num_shards"].(int),
+			ZoneName:      replicationSpec["zone_name"].(string),
+			RegionConfigs: configs,
+		}
+	}
+	return specs
+}
+
+func readHardwareSpecFromSchema(hardwareSpecs []interface{}) *ma.HardwareSpec {
+	if len(hardwareSpecs) == 0 || hardwareSpecs[0] == nil {
+		return nil
+	}
+	hardwareSpec := hardwareSpecs[0].(map[string]interface{})
+	nodeCount := hardwareSpec["node_count"].(int)
+	return &ma.HardwareSpec{
+		InstanceSize: hardwareSpec["instance_size"].(string),
+		NodeCount:    &nodeCount,
+	}
+}
+
+// flattenAdvancedReplicationSpecs keeps the hardware specs without nodes which are configured,
+// at the same position, in the configured replication specs
+func flattenAdvancedReplicationSpecs(replicationSpecs []ma.AdvancedReplicationSpec, configured []interface{}) []interface{} {
+	specs := make([]interface{}, len(replicationSpecs))
+	for i, r := range replicationSpecs {
+		configuredRegions := []interface{}{}
+		if i < len(configured) && configured[i] != nil {
+			configuredRegions = configured[i].(map[string]interface{})["region_configs"].([]interface{})
+		}
+
+		configs := make([]interface{}, len(r.RegionConfigs))
+		for j, rc := range r.RegionConfigs {
+			priority := 0
+			if rc.Priority != nil {
+				priority = *rc.Priority
+			}
+			configuredRegion := map[string]interface{}{}
+			if j < len(configuredRegions) && configuredRegions[j] != nil {
+				configuredRegion = configuredRegions[j].(map[string]interface{})
+			}
+			configs[j] = map[string]interface{}{
+				"provider_name":         rc.ProviderName,
+				"backing_provider_name": rc.BackingProviderName,
+				"region_name":           rc.RegionName,
+				"priority":              priority,
+				"electable_specs":       flattenHardwareSpec(rc.ElectableSpecs, isHardwareSpecConfigured(configuredRegion, "electable_specs")),
+				"read_only_specs":       flattenHardwareSpec(rc.ReadOnlySpecs, isHardwareSpecConfigured(configuredRegion, "read_only_specs")),
+				"analytics_specs":       flattenHardwareSpec(rc.AnalyticsSpecs, isHardwareSpecConfigured(configuredRegion, "analytics_specs")),
+			}
+		}
+
+		specs[i] = map[string]interface{}{
+			"id":             r.ID,
+			"num_shards":     r.NumShards,
+			"zone_name":      r.ZoneName,
+			"region_configs": configs,
+		}
+	}
+	return specs
+}
+
+func isHardwareSpecConfigured(regionConfig map[string]interface{}, k string) bool {
+	specs, ok := regionConfig[k].([]interface{})
+	return ok && len(specs) > 0
+}
+
+// flattenHardwareSpec leaves out specs without nodes, Atlas returns them for every region,
+// unless they are configured
+func flattenHardwareSpec(hardwareSpec *ma.HardwareSpec, configured bool) []interface{} {
+	if hardwareSpec == nil {
+		return []interface{}{}
+	}
+	nodeCount := 0
+	if hardwareSpec.NodeCount != nil {
+		nodeCount = *hardwareSpec.NodeCount
+	}
+	if nodeCount == 0 && !configured {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"instance_size": hardwareSpec.InstanceSize,
+			"node_count":    nodeCount,
+		},
+	}
+}
@@ -0,0 +1,233 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMongodbatlasAdvancedCluster_basic(t *testing.T) {
+	var cluster ma.AdvancedCluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_advanced_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasAdvancedClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasAdvancedCluster(projectName, clusterName, "M10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasAdvancedClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttrSet(resourceName, "identifier"),
+					resource.TestCheckResourceAttrSet(resourceName, "group"),
+					resource.TestCheckResourceAttrSet(resourceName, "mongodb_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "state"),
+					resource.TestCheckResourceAttrSet(resourceName, "replication_specs.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
+					resource.TestCheckResourceAttr(resourceName, "cluster_type", "REPLICASET"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.0.provider_name", "AWS"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.0.electable_specs.0.instance_size", "M10"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.0.electable_specs.0.node_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.0.analytics_specs.0.node_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.1.provider_name", "GCP"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.1.electable_specs.0.node_count", "2"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasAdvancedCluster(projectName, clusterName, "M20"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasAdvancedClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.0.electable_specs.0.instance_size", "M20"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.1.electable_specs.0.instance_size", "M20"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMongodbatlasAdvancedClusterExists(n string, res *ma.AdvancedCluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Advanced Cluster ID is set")
+		}
+
+		if rs.Primary.Attributes["name"] == "" {
+			return errors.New("No Advanced Cluster name is set")
+		}
+
+		client := testAccProvider.Meta().(*MongoDBClient).Client
+
+		c, _, err := client.AdvancedClusters.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if c.ID != rs.Primary.ID {
+			return fmt.Errorf("Advanced Cluster not found")
+		}
+
+		*res = *c
+		return nil
+	}
+}
+
+func testAccCheckMongodbatlasAdvancedClusterDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*MongoDBClient).Client
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodbatlas_advanced_cluster" {
+			continue
+		}
+
+		// Try to find the advanced cluster
+		c, resp, err := client.AdvancedClusters.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["name"])
+
+		if err == nil {
+			if c.StateName != "DELETED" {
+				return fmt.Errorf("Advanced Cluster %q still exists", rs.Primary.ID)
+			}
+		}
+
+		// Verify the error
+		if err != nil && resp.StatusCode != 404 {
+			return fmt.Errorf("Error reading MongoDB Advanced Cluster: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccMongodbatlasAdvancedCluster(projectName, clusterName, size string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_advanced_cluster" "test" {
+  name         = "%s"
+  group        = "${data.mongodbatlas_project.test.id}"
+  cluster_type = "REPLICASET"
+
+  replication_specs {
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+
+      electable_specs {
+        instance_size = "%s"
+        node_count    = 3
+      }
+
+      analytics_specs {
+        instance_size = "%s"
+        node_count    = 1
+      }
+    }
+
+    region_configs {
+      provider_name = "GCP"
+      region_name   = "CENTRAL_US"
+      priority      = 6
+
+      electable_specs {
+        instance_size = "%s"
+        node_count    = 2
+      }
+    }
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, size, size, size, projectName)
+}
+
+func TestMongodbatlasAdvancedCluster_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceAdvancedCluster(), map[string]interface{}{
+		"group": "5ba8c5c396e8211ae8272486",
+		"name":  "test",
+	}, "5ba8c5c396e8211ae8272487")
+}
+
+func TestMongodbatlasAdvancedCluster_flattenZeroNodeSpecs(t *testing.T) {
+	priority, electableNodes, noNodes := 7, 3, 0
+	replicationSpecs := []ma.AdvancedReplicationSpec{
+		{
+			NumShards: 1,
+			ZoneName:  "Zone 1",
+			RegionConfigs: []ma.RegionConfig{
+				{
+					ProviderName:   "AWS",
+					RegionName:     "US_EAST_1",
+					Priority:       &priority,
+					ElectableSpecs: &ma.HardwareSpec{InstanceSize: "M10", NodeCount: &electableNodes},
+					ReadOnlySpecs:  &ma.HardwareSpec{InstanceSize: "M10", NodeCount: &noNodes},
+					AnalyticsSpecs: &ma.HardwareSpec{InstanceSize: "M10", NodeCount: &noNodes},
+				},
+			},
+		},
+	}
+	configured := []interface{}{
+		map[string]interface{}{
+			"region_configs": []interface{}{
+				map[string]interface{}{
+					"electable_specs": []interface{}{map[string]interface{}{"instance_size": "M10", "node_count": 3}},
+					"read_only_specs": []interface{}{map[string]interface{}{"instance_size": "M10", "node_count": 0}},
+					"analytics_specs": []interface{}{},
+				},
+			},
+		},
+	}
+
+	regionConfig := flattenAdvancedReplicationSpecs(replicationSpecs, configured)[0].(map[string]interface{})["region_configs"].([]interface{})[0].(map[string]interface{})
+	if specs := regionConfig["read_only_specs"].([]interface{}); len(specs) != 1 || specs[0].(map[string]interface{})["node_count"] != 0 {
+		t.Fatalf("expected the configured read_only_specs without nodes to be kept, got %#v", specs)
+	}
+	if specs := regionConfig["analytics_specs"].([]interface{}); len(specs) != 0 {
+		t.Fatalf("expected the analytics_specs without nodes to be left out, got %#v", specs)
+	}
+	if specs := regionConfig["electable_specs"].([]interface{}); len(specs) != 1 {
+		t.Fatalf("expected the electable_specs to be kept, got %#v", specs)
+	}
+}
+
+func TestMongodbatlasAdvancedCluster_stateRefresh(t *testing.T) {
+	// Only the advanced clusters API knows about clusters with multiple providers
+	meta := testOfflineClient(http.StatusOK, `{"id":"5ba8c5c396e8211ae8272487","name":"test","groupId":"5ba8c5c396e8211ae8272486","clusterType":"REPLICASET","stateName":"UPDATING","replicationSpecs":[{"numShards":1,"zoneName":"Zone 1","regionConfigs":[{"providerName":"AWS","regionName":"US_EAST_1","priority":7},{"providerName":"GCP","regionName":"CENTRAL_US","priority":6}]}]}`)
+	c, state, err := resourceAdvancedClusterStateRefreshFunc("test", "5ba8c5c396e8211ae8272486", meta.Client)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != "UPDATING" {
+		t.Fatalf("expected the state to be UPDATING, got %q", state)
+	}
+	if _, ok := c.(*ma.AdvancedCluster); !ok {
+		t.Fatalf("expected an advanced cluster, got %#v", c)
+	}
+
+	meta = testOfflineClient(http.StatusNotFound, `{"detail":"Not found.","error":404,"errorCode":"CLUSTER_NOT_FOUND","reason":"Not Found"}`)
+	_, state, err = resourceAdvancedClusterStateRefreshFunc("test", "5ba8c5c396e8211ae8272486", meta.Client)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != "DELETED" {
+		t.Fatalf("expected the state to be DELETED, got %q", state)
+	}
+
+	meta = testOfflineClient(http.StatusInternalServerError, `{"detail":"Unexpected error.","error":500,"errorCode":"UNEXPECTED_ERROR","reason":"Internal Server Error"}`)
+	if _, _, err := resourceAdvancedClusterStateRefreshFunc("test", "5ba8c5c396e8211ae8272486", meta.Client)(); err == nil {
+		t.Fatal("expected an error")
+	}
+}
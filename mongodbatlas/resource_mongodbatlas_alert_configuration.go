@@ -177,7 +177,7 @@ func resourceAlertConfiguration() *schema.Resource {
 }
 
 func resourceAlertConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	params := alertConfigurationFromResourceData(d)
 
@@ -193,11 +193,12 @@ func resourceAlertConfigurationCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAlertConfigurationRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	alert, response, err := client.AlertConfigurations.Get(d.Get("group").(string), d.Id())
 	if err != nil {
-		if response.StatusCode == 404 {
+		if isNotFound(response) {
+			log.Printf("[WARN] MongoDB Alert Configuration %s not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -209,7 +210,7 @@ func resourceAlertConfigurationRead(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAlertConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	params := alertConfigurationFromResourceData(d)
 
@@ -223,13 +224,13 @@ func resourceAlertConfigurationUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAlertConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	log.Printf("[DEBUG] MongoDB Alert Configuration destroy: %v", d.Id())
 
 	response, err := client.AlertConfigurations.Delete(d.Get("group").(string), d.Id())
 	if err != nil {
-		if response.StatusCode == 404 {
+		if isNotFound(response) {
 			d.SetId("")
 			return nil
 		}
@@ -0,0 +1,12 @@
+package mongodbatlas
+
+import (
+	"testing"
+)
+
+func TestMongodbatlasAlertConfiguration_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceAlertConfiguration(), map[string]interface{}{
+		"group":           "5ba8c5c396e8211ae8272486",
+		"event_type_name": "OUTSIDE_METRIC_THRESHOLD",
+	}, "5ba8c5c396e8211ae8272487")
+}
@@ -4,24 +4,40 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"reflect"
 	"strings"
 	"time"
 
 	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
+// defaultZoneName is the name Atlas gives to the zone of clusters created with the legacy replicationSpec
+const defaultZoneName = "Zone 1"
+
 func resourceCluster() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceClusterCreate,
-		Read:   resourceClusterRead,
-		Update: resourceClusterUpdate,
-		Delete: resourceClusterDelete,
+		Create:        resourceClusterCreate,
+		Read:          resourceClusterRead,
+		Update:        resourceClusterUpdate,
+		Delete:        resourceClusterDelete,
+		CustomizeDiff: resourceClusterCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceClusterImportState,
 		},
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceClusterResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceClusterStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Update: schema.DefaultTimeout(40 * time.Minute),
@@ -56,16 +72,20 @@ func resourceCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: false,
+				// Atlas picks the instance size when compute auto-scaling is enabled
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old != "" && d.Get("auto_scaling_compute_enabled").(bool)
+				},
 			},
+			// Changes of provider_name and backing_provider replace the cluster,
+			// except for tenant upgrades, see resourceClusterCustomizeDiff
 			"provider_name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"backing_provider": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"region": {
 				Type:     schema.TypeString,
@@ -85,18 +105,69 @@ func resourceCluster() *schema.Resource {
 			"num_shards": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				Default:  1,
+				Computed: true,
+			},
+			"cluster_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"REPLICASET", "SHARDED", "GEOSHARDED"}, false),
 			},
 			"paused": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"termination_protection_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Only used at plan time, see validateClusterReplacement
+			"allow_replacement": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"disk_gb_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
+			"provider_disk_iops": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"provider_encrypt_ebs_volume": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"provider_volume_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"STANDARD", "PROVISIONED"}, false),
+			},
+			"auto_scaling_compute_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"auto_scaling_compute_scale_down_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"provider_auto_scaling_compute_min_instance_size": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"provider_auto_scaling_compute_max_instance_size": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"identifier": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -133,33 +204,206 @@ func resourceCluster() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"connection_strings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"standard": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"standard_srv": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_srv": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"aws_private_link": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"aws_private_link_srv": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"private_endpoint": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"connection_string": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"srv_connection_string": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"endpoints": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"endpoint_id": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"provider_name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"region": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"replica_set_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hosts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"replication_spec": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"replication_specs"},
+				Elem:          clusterRegionConfigResource(),
+			},
+			"replication_specs": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"replication_spec"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zone_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  defaultZoneName,
+						},
+						"num_shards": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+						"regions_config": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     clusterRegionConfigResource(),
+						},
+					},
+				},
+			},
+			"labels": {
 				Type:     schema.TypeSet,
 				Optional: true,
-				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"region": {
+						"key": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
-						"priority": {
-							Type:     schema.TypeInt,
+						"value": {
+							Type:     schema.TypeString,
 							Required: true,
 						},
-						"electable_nodes": {
-							Type:     schema.TypeInt,
-							Required: true,
+					},
+				},
+			},
+			"effective_labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"bi_connector": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
 						},
-						"read_only_nodes": {
-							Type:     schema.TypeInt,
+						"read_preference": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"primary", "secondary", "analytics"}, false),
+						},
+					},
+				},
+			},
+			"advanced_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fail_index_key_too_long": {
+							Type:     schema.TypeBool,
 							Optional: true,
-							Default:  0,
+							Computed: true,
 						},
-						"analytics_nodes": {
-							Type:     schema.TypeInt,
+						"javascript_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"minimum_enabled_tls_protocol": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"TLS1_0", "TLS1_1", "TLS1_2"}, false),
+						},
+						"no_table_scan": {
+							Type:     schema.TypeBool,
 							Optional: true,
-							Default:  0,
+							Computed: true,
+						},
+						"oplog_size_mb": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(990),
+						},
+						"sample_size_bi_connector": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(0),
 						},
 					},
 				},
@@ -168,30 +412,96 @@ func resourceCluster() *schema.Resource {
 	}
 }
 
+// clusterRegionConfigResource is the replication spec of a region, shared by the
+// legacy replication_spec and the regions_config of each zone.
+func clusterRegionConfigResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"priority": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"electable_nodes": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"read_only_nodes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"analytics_nodes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+		},
+	}
+}
+
 func resourceClusterCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
+	defaultLabels := meta.(*MongoDBClient).DefaultLabels
 
 	providerSettings := ma.ProviderSettings{
 		ProviderName:        d.Get("provider_name").(string),
 		BackingProviderName: d.Get("backing_provider").(string),
 		RegionName:          d.Get("region").(string),
 		InstanceSizeName:    d.Get("size").(string),
+		VolumeType:          d.Get("provider_volume_type").(string),
+	}
+	if providerSettings.VolumeType == "PROVISIONED" {
+		providerSettings.DiskIOPS = d.Get("provider_disk_iops").(int)
 	}
-	autoScaling := ma.AutoScaling{
-		DiskGBEnabled: d.Get("disk_gb_enabled").(bool),
+	if v, ok := d.GetOkExists("provider_encrypt_ebs_volume"); ok {
+		encryptEBSVolume := v.(bool)
+		providerSettings.EncryptEBSVolume = &encryptEBSVolume
 	}
 	params := ma.Cluster{
-		Name:                  d.Get("name").(string),
-		MongoDBMajorVersion:   d.Get("mongodb_major_version").(string),
-		ProviderSettings:      providerSettings,
-		BackupEnabled:         d.Get("backup").(bool),
-		ProviderBackupEnabled: d.Get("provider_backup").(bool),
-		ReplicationFactor:     d.Get("replication_factor").(int),
-		ReplicationSpec:       readReplicationSpecsFromSchema(d.Get("replication_spec").(*schema.Set).List()),
-		DiskSizeGB:            d.Get("disk_size_gb").(float64),
-		NumShards:             d.Get("num_shards").(int),
-		Paused:                d.Get("paused").(bool),
-		AutoScaling:           autoScaling,
+		Name:                d.Get("name").(string),
+		MongoDBMajorVersion: d.Get("mongodb_major_version").(string),
+		ProviderSettings:    providerSettings,
+		Paused:              d.Get("paused").(bool),
+		Labels:              readLabelsFromSchema(d.Get("labels").(*schema.Set).List(), defaultLabels),
+	}
+	if v, ok := d.GetOk("termination_protection_enabled"); ok {
+		terminationProtection := v.(bool)
+		params.TerminationProtection = &terminationProtection
+	}
+	// Shared-tier clusters have a fixed topology, disk and backup, Atlas rejects these settings for them
+	if providerSettings.ProviderName != "TENANT" {
+		backup := d.Get("backup").(bool)
+		providerBackup := d.Get("provider_backup").(bool)
+		autoScaling := ma.AutoScaling{
+			DiskGBEnabled: d.Get("disk_gb_enabled").(bool),
+		}
+		if d.Get("auto_scaling_compute_enabled").(bool) {
+			autoScaling.Compute = readComputeAutoScalingFromSchema(d)
+			params.ProviderSettings.AutoScaling = readProviderAutoScalingFromSchema(d)
+		}
+		params.BackupEnabled = &backup
+		params.ProviderBackupEnabled = &providerBackup
+		params.ReplicationFactor = d.Get("replication_factor").(int)
+		params.ReplicationSpec = readReplicationSpecsFromSchema(d.Get("replication_spec").(*schema.Set).List())
+		params.DiskSizeGB = d.Get("disk_size_gb").(float64)
+		params.NumShards = d.Get("num_shards").(int)
+		params.AutoScaling = &autoScaling
+
+		if v, ok := d.GetOk("cluster_type"); ok {
+			params.ClusterType = v.(string)
+		}
+		if v, ok := d.GetOk("replication_specs"); ok {
+			params.ReplicationSpecs = readZoneReplicationSpecsFromSchema(v.([]interface{}))
+			params.ReplicationSpec = nil
+			params.NumShards = 0
+		}
+		if _, ok := d.GetOk("bi_connector"); ok {
+			params.BiConnector = readBiConnectorFromSchema(d)
+		}
 	}
 
 	cluster, _, err := client.Clusters.Create(d.Get("group").(string), &params)
@@ -218,36 +528,47 @@ func resourceClusterCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if _, ok := d.GetOk("advanced_configuration"); ok {
+		if err := updateClusterProcessArgs(d, client, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceClusterRead(d, meta)
 }
 
 func resourceClusterRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
+	defaultLabels := meta.(*MongoDBClient).DefaultLabels
 
 	c, resp, err := client.Clusters.Get(d.Get("group").(string), d.Get("name").(string))
 	if err != nil {
-		if resp.StatusCode == 404 {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Cluster %s not found, removing from state", d.Get("name").(string))
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("Error reading MongoDB Cluster %s: %s", d.Get("name").(string), err)
 	}
 
-	replicationSpecs := []interface{}{}
-	for region, replicationSpec := range c.ReplicationSpec {
-		spec := map[string]interface{}{
-			"region":          region,
-			"priority":        replicationSpec.Priority,
-			"electable_nodes": replicationSpec.ElectableNodes,
-			"read_only_nodes": replicationSpec.ReadOnlyNodes,
-			"analytics_nodes": replicationSpec.AnalyticsNodes,
-		}
-		replicationSpecs = append(replicationSpecs, spec)
+	zones := c.ReplicationSpecs
+	if len(zones) == 0 {
+		zones = zoneReplicationSpecsFromLegacy(c.ReplicationSpec, c.NumShards)
+	}
+	legacySpec := c.ReplicationSpec
+	if len(legacySpec) == 0 && len(zones) == 1 {
+		legacySpec = zones[0].RegionsConfig
 	}
 
-	if err := d.Set("replication_spec", replicationSpecs); err != nil {
+	if err := d.Set("replication_spec", flattenRegionsConfig(legacySpec)); err != nil {
 		log.Printf("[WARN] Error setting replication specs set for (%s): %s", d.Get("name"), err)
 	}
+	if err := d.Set("replication_specs", flattenZoneReplicationSpecs(zones)); err != nil {
+		log.Printf("[WARN] Error setting replication_specs for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("cluster_type", c.ClusterType); err != nil {
+		log.Printf("[WARN] Error setting cluster_type for (%s): %s", d.Get("name"), err)
+	}
 
 	if err := d.Set("name", c.Name); err != nil {
 		log.Printf("[WARN] Error setting name for (%s): %s", d.Get("name"), err)
@@ -258,11 +579,15 @@ func resourceClusterRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("mongodb_major_version", c.MongoDBMajorVersion); err != nil {
 		log.Printf("[WARN] Error setting mongodb_major_version for (%s): %s", d.Get("name"), err)
 	}
-	if err := d.Set("backup", c.BackupEnabled); err != nil {
-		log.Printf("[WARN] Error setting backup for (%s): %s", d.Get("name"), err)
+	if c.BackupEnabled != nil {
+		if err := d.Set("backup", *c.BackupEnabled); err != nil {
+			log.Printf("[WARN] Error setting backup for (%s): %s", d.Get("name"), err)
+		}
 	}
-	if err := d.Set("provider_backup", c.ProviderBackupEnabled); err != nil {
-		log.Printf("[WARN] Error setting provider_backup for (%s): %s", d.Get("name"), err)
+	if c.ProviderBackupEnabled != nil {
+		if err := d.Set("provider_backup", *c.ProviderBackupEnabled); err != nil {
+			log.Printf("[WARN] Error setting provider_backup for (%s): %s", d.Get("name"), err)
+		}
 	}
 	if err := d.Set("size", c.ProviderSettings.InstanceSizeName); err != nil {
 		log.Printf("[WARN] Error setting size for (%s): %s", d.Get("name"), err)
@@ -279,11 +604,69 @@ func resourceClusterRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("disk_size_gb", c.DiskSizeGB); err != nil {
 		log.Printf("[WARN] Error setting disk_size_gb for (%s): %s", d.Get("name"), err)
 	}
-	if err := d.Set("disk_gb_enabled", c.AutoScaling.DiskGBEnabled); err != nil {
-		log.Printf("[WARN] Error setting disk_gb_enabled for (%s): %s", d.Get("name"), err)
+	if err := d.Set("provider_disk_iops", c.ProviderSettings.DiskIOPS); err != nil {
+		log.Printf("[WARN] Error setting provider_disk_iops for (%s): %s", d.Get("name"), err)
+	}
+	if c.ProviderSettings.EncryptEBSVolume != nil {
+		if err := d.Set("provider_encrypt_ebs_volume", *c.ProviderSettings.EncryptEBSVolume); err != nil {
+			log.Printf("[WARN] Error setting provider_encrypt_ebs_volume for (%s): %s", d.Get("name"), err)
+		}
+	}
+	if err := d.Set("provider_volume_type", c.ProviderSettings.VolumeType); err != nil {
+		log.Printf("[WARN] Error setting provider_volume_type for (%s): %s", d.Get("name"), err)
+	}
+	if c.AutoScaling != nil {
+		if err := d.Set("disk_gb_enabled", c.AutoScaling.DiskGBEnabled); err != nil {
+			log.Printf("[WARN] Error setting disk_gb_enabled for (%s): %s", d.Get("name"), err)
+		}
+	}
+	computeEnabled, computeScaleDownEnabled := false, false
+	if c.AutoScaling != nil && c.AutoScaling.Compute != nil {
+		computeEnabled = c.AutoScaling.Compute.Enabled
+		computeScaleDownEnabled = c.AutoScaling.Compute.ScaleDownEnabled
+	}
+	if err := d.Set("auto_scaling_compute_enabled", computeEnabled); err != nil {
+		log.Printf("[WARN] Error setting auto_scaling_compute_enabled for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("auto_scaling_compute_scale_down_enabled", computeScaleDownEnabled); err != nil {
+		log.Printf("[WARN] Error setting auto_scaling_compute_scale_down_enabled for (%s): %s", d.Get("name"), err)
+	}
+	minInstanceSize, maxInstanceSize := "", ""
+	if c.ProviderSettings.AutoScaling != nil {
+		minInstanceSize = c.ProviderSettings.AutoScaling.Compute.MinInstanceSize
+		maxInstanceSize = c.ProviderSettings.AutoScaling.Compute.MaxInstanceSize
+	}
+	if err := d.Set("provider_auto_scaling_compute_min_instance_size", minInstanceSize); err != nil {
+		log.Printf("[WARN] Error setting provider_auto_scaling_compute_min_instance_size for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("provider_auto_scaling_compute_max_instance_size", maxInstanceSize); err != nil {
+		log.Printf("[WARN] Error setting provider_auto_scaling_compute_max_instance_size for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("labels", flattenLabels(c.Labels, d.Get("labels").(*schema.Set).List(), defaultLabels)); err != nil {
+		log.Printf("[WARN] Error setting labels for (%s): %s", d.Get("name"), err)
+	}
+	effectiveLabels := map[string]string{}
+	for _, l := range c.Labels {
+		effectiveLabels[l.Key] = l.Value
 	}
-	if err := d.Set("replication_factor", c.ReplicationFactor); err != nil {
-		log.Printf("[WARN] Error setting replication_factor for (%s): %s", d.Get("name"), err)
+	if err := d.Set("effective_labels", effectiveLabels); err != nil {
+		log.Printf("[WARN] Error setting effective_labels for (%s): %s", d.Get("name"), err)
+	}
+	if c.BiConnector != nil {
+		biConnector := []interface{}{
+			map[string]interface{}{
+				"enabled":         c.BiConnector.Enabled,
+				"read_preference": c.BiConnector.ReadPreference,
+			},
+		}
+		if err := d.Set("bi_connector", biConnector); err != nil {
+			log.Printf("[WARN] Error setting bi_connector for (%s): %s", d.Get("name"), err)
+		}
+	}
+	if c.ReplicationFactor != 0 {
+		if err := d.Set("replication_factor", c.ReplicationFactor); err != nil {
+			log.Printf("[WARN] Error setting replication_factor for (%s): %s", d.Get("name"), err)
+		}
 	}
 	if err := d.Set("identifier", c.ID); err != nil {
 		log.Printf("[WARN] Error setting identifier for (%s): %s", d.Get("name"), err)
@@ -297,6 +680,11 @@ func resourceClusterRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("paused", c.Paused); err != nil {
 		log.Printf("[WARN] Error setting paused for (%s): %s", d.Get("name"), err)
 	}
+	if c.TerminationProtection != nil {
+		if err := d.Set("termination_protection_enabled", *c.TerminationProtection); err != nil {
+			log.Printf("[WARN] Error setting termination_protection_enabled for (%s): %s", d.Get("name"), err)
+		}
+	}
 	if err := d.Set("mongodb_version", c.MongoDBVersion); err != nil {
 		log.Printf("[WARN] Error setting mongodb_version for (%s): %s", d.Get("name"), err)
 	}
@@ -312,40 +700,103 @@ func resourceClusterRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("srv_address", c.SrvAddress); err != nil {
 		log.Printf("[WARN] Error setting srv_address for (%s): %s", d.Get("name"), err)
 	}
+	if err := d.Set("connection_strings", flattenConnectionStrings(c.ConnectionStrings)); err != nil {
+		log.Printf("[WARN] Error setting connection_strings for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("replica_set_name", parseReplicaSetName(c.MongoURIWithOptions)); err != nil {
+		log.Printf("[WARN] Error setting replica_set_name for (%s): %s", d.Get("name"), err)
+	}
+	if err := d.Set("hosts", parseMongoURIHosts(c.MongoURI)); err != nil {
+		log.Printf("[WARN] Error setting hosts for (%s): %s", d.Get("name"), err)
+	}
+
+	// Shared tier clusters don't support advanced configuration options
+	if c.ProviderSettings.ProviderName != "TENANT" {
+		p, _, err := client.Clusters.GetProcessArgs(d.Get("group").(string), d.Get("name").(string))
+		if err != nil {
+			return fmt.Errorf("Error reading advanced configuration of MongoDB Cluster %s: %s", d.Get("name").(string), err)
+		}
+		if err := d.Set("advanced_configuration", flattenProcessArgs(p)); err != nil {
+			log.Printf("[WARN] Error setting advanced_configuration for (%s): %s", d.Get("name"), err)
+		}
+	}
 
 	return nil
 }
 
 func resourceClusterUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
+	defaultLabels := meta.(*MongoDBClient).DefaultLabels
 	requestUpdate := false
+	// Labels and termination protection don't change the topology of the cluster, so there is nothing to wait for
+	updateWithoutWait := false
 
 	c, _, err := client.Clusters.Get(d.Get("group").(string), d.Get("name").(string))
 	if err != nil {
 		return fmt.Errorf("Error reading MongoDB Cluster %s: %s", d.Get("name").(string), err)
 	}
 
+	// Shared-tier clusters are moved to a dedicated instance size through their own endpoint
+	upgradedTenant := false
+	if o, n := d.GetChange("provider_name"); o.(string) == "TENANT" && n.(string) != "TENANT" {
+		if err := upgradeTenantCluster(d, client); err != nil {
+			return err
+		}
+		upgradedTenant = true
+
+		c, _, err = client.Clusters.Get(d.Get("group").(string), d.Get("name").(string))
+		if err != nil {
+			return fmt.Errorf("Error reading MongoDB Cluster %s: %s", d.Get("name").(string), err)
+		}
+	}
+
+	// Atlas leaves out empty labels, which mustn't be sent back as null
+	if c.Labels == nil {
+		c.Labels = []ma.Label{}
+	}
+
 	if d.HasChange("mongodb_major_version") {
 		c.MongoDBMajorVersion = d.Get("mongodb_major_version").(string)
 		requestUpdate = true
 	}
 
 	if d.HasChange("backup") {
-		c.BackupEnabled = d.Get("backup").(bool)
+		backup := d.Get("backup").(bool)
+		c.BackupEnabled = &backup
 		requestUpdate = true
 	}
 	if d.HasChange("provider_backup") {
-		c.ProviderBackupEnabled = d.Get("provider_backup").(bool)
+		providerBackup := d.Get("provider_backup").(bool)
+		c.ProviderBackupEnabled = &providerBackup
 		requestUpdate = true
 	}
-	if d.HasChange("size") {
+	if d.HasChange("size") && !upgradedTenant {
 		c.ProviderSettings.InstanceSizeName = d.Get("size").(string)
 		requestUpdate = true
 	}
 	if d.HasChange("disk_size_gb") {
 		c.DiskSizeGB = d.Get("disk_size_gb").(float64)
-		// Don't provide IOPS on disk update, it will be calculated
-		c.ProviderSettings.DiskIOPS = 0
+		requestUpdate = true
+	}
+	if d.HasChange("provider_volume_type") {
+		c.ProviderSettings.VolumeType = d.Get("provider_volume_type").(string)
+		requestUpdate = true
+	}
+	if d.HasChange("provider_disk_iops") {
+		requestUpdate = true
+	}
+	if d.HasChange("disk_size_gb") || d.HasChange("provider_volume_type") || d.HasChange("provider_disk_iops") {
+		if d.Get("provider_volume_type").(string) == "PROVISIONED" {
+			// Keep the provisioned IOPS, Atlas would reset them to the default of the new disk size otherwise
+			c.ProviderSettings.DiskIOPS = d.Get("provider_disk_iops").(int)
+		} else {
+			// Don't provide IOPS on disk update, it will be calculated
+			c.ProviderSettings.DiskIOPS = 0
+		}
+	}
+	if d.HasChange("provider_encrypt_ebs_volume") {
+		encryptEBSVolume := d.Get("provider_encrypt_ebs_volume").(bool)
+		c.ProviderSettings.EncryptEBSVolume = &encryptEBSVolume
 		requestUpdate = true
 	}
 	if d.HasChange("replication_factor") {
@@ -360,16 +811,63 @@ func resourceClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 		c.NumShards = d.Get("num_shards").(int)
 		requestUpdate = true
 	}
+	if d.HasChange("replication_specs") {
+		c.ReplicationSpecs = readZoneReplicationSpecsFromSchema(d.Get("replication_specs").([]interface{}))
+		requestUpdate = true
+	}
+	// Atlas accepts only one of the replication spec formats
+	if d.HasChange("replication_spec") || d.HasChange("num_shards") {
+		c.ReplicationSpecs = nil
+	} else if len(c.ReplicationSpecs) > 0 {
+		c.ReplicationSpec = nil
+		c.NumShards = 0
+	}
+	if d.HasChange("cluster_type") {
+		c.ClusterType = d.Get("cluster_type").(string)
+		requestUpdate = true
+	}
 	if d.HasChange("paused") {
 		c.Paused = d.Get("paused").(bool)
 		requestUpdate = true
 	}
+	if d.HasChange("termination_protection_enabled") {
+		terminationProtection := d.Get("termination_protection_enabled").(bool)
+		c.TerminationProtection = &terminationProtection
+		updateWithoutWait = true
+	}
 	if d.HasChange("disk_gb_enabled") {
+		if c.AutoScaling == nil {
+			c.AutoScaling = &ma.AutoScaling{}
+		}
 		c.AutoScaling.DiskGBEnabled = d.Get("disk_gb_enabled").(bool)
 		requestUpdate = true
 	}
+	if d.HasChange("labels") || d.HasChange("effective_labels") {
+		c.Labels = readLabelsFromSchema(d.Get("labels").(*schema.Set).List(), defaultLabels)
+		updateWithoutWait = true
+	}
+	if d.HasChange("bi_connector") {
+		c.BiConnector = readBiConnectorFromSchema(d)
+		requestUpdate = true
+	}
+	if d.HasChange("auto_scaling_compute_enabled") || d.HasChange("auto_scaling_compute_scale_down_enabled") ||
+		d.HasChange("provider_auto_scaling_compute_min_instance_size") || d.HasChange("provider_auto_scaling_compute_max_instance_size") {
+		if c.AutoScaling == nil {
+			c.AutoScaling = &ma.AutoScaling{}
+		}
+		c.AutoScaling.Compute = readComputeAutoScalingFromSchema(d)
+		if d.Get("auto_scaling_compute_enabled").(bool) {
+			c.ProviderSettings.AutoScaling = readProviderAutoScalingFromSchema(d)
+		} else {
+			c.ProviderSettings.AutoScaling = nil
+		}
+		requestUpdate = true
+	}
 
-	if requestUpdate {
+	if requestUpdate || updateWithoutWait {
+		if c.ProviderSettings.ProviderName == "TENANT" {
+			clearTenantUnsupportedFields(c)
+		}
 		// Set read-only fields to an empty string to make the API happy
 		c.StateName = ""
 		c.MongoDBVersion = ""
@@ -377,11 +875,14 @@ func resourceClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 		c.MongoURIWithOptions = ""
 		c.MongoURIUpdated = ""
 		c.SrvAddress = ""
+		c.ConnectionStrings = nil
 		_, _, err := client.Clusters.Update(d.Get("group").(string), d.Get("name").(string), c)
 		if err != nil {
 			return fmt.Errorf("Error reading MongoDB Cluster %s: %s", d.Get("name").(string), err)
 		}
+	}
 
+	if requestUpdate {
 		log.Println("[INFO] Waiting for MongoDB Cluster to be updated")
 
 		stateConf := &resource.StateChangeConf{
@@ -399,11 +900,22 @@ func resourceClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 	}
+
+	if d.HasChange("advanced_configuration") {
+		if err := updateClusterProcessArgs(d, client, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceClusterRead(d, meta)
 }
 
 func resourceClusterDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
+
+	if d.Get("termination_protection_enabled").(bool) {
+		return fmt.Errorf("MongoDB Cluster %s has termination_protection_enabled, set it to false and apply before destroying the cluster", d.Get("name").(string))
+	}
 
 	log.Printf("[DEBUG] MongoDB Cluster destroy: %v", d.Id())
 	_, err := client.Clusters.Delete(d.Get("group").(string), d.Get("name").(string))
@@ -432,7 +944,7 @@ func resourceClusterDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceClusterImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	parts := strings.SplitN(d.Id(), "-", 2)
 	if len(parts) != 2 {
@@ -461,7 +973,7 @@ func resourceClusterStateRefreshFunc(name, group string, client *ma.Client) reso
 	return func() (interface{}, string, error) {
 		c, resp, err := client.Clusters.Get(group, name)
 		if err != nil {
-			if resp.StatusCode == 404 {
+			if isNotFound(resp) {
 				return 42, "DELETED", nil
 			}
 			log.Printf("Error reading MongoDB Cluster %s: %s", name, err)
@@ -476,6 +988,399 @@ func resourceClusterStateRefreshFunc(name, group string, client *ma.Client) reso
 	}
 }
 
+func resourceClusterCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("auto_scaling_compute_enabled").(bool) {
+		if d.Get("provider_auto_scaling_compute_max_instance_size").(string) == "" {
+			return errors.New("provider_auto_scaling_compute_max_instance_size is required when auto_scaling_compute_enabled is true")
+		}
+		if d.Get("auto_scaling_compute_scale_down_enabled").(bool) && d.Get("provider_auto_scaling_compute_min_instance_size").(string) == "" {
+			return errors.New("provider_auto_scaling_compute_min_instance_size is required when auto_scaling_compute_scale_down_enabled is true")
+		}
+	} else if d.Get("auto_scaling_compute_scale_down_enabled").(bool) {
+		return errors.New("auto_scaling_compute_scale_down_enabled requires auto_scaling_compute_enabled to be true")
+	}
+
+	if err := validateClusterStorage(d); err != nil {
+		return err
+	}
+
+	if err := validateTenantCluster(d); err != nil {
+		return err
+	}
+	if err := validateClusterReplacement(d); err != nil {
+		return err
+	}
+
+	catalog := defaultClusterCatalog()
+	if meta != nil && meta.(*MongoDBClient).ClusterCatalog != nil {
+		catalog = meta.(*MongoDBClient).ClusterCatalog
+	}
+	if err := validateClusterCatalog(d, catalog); err != nil {
+		return err
+	}
+
+	var defaultLabels map[string]string
+	if meta != nil {
+		defaultLabels = meta.(*MongoDBClient).DefaultLabels
+	}
+	if err := planEffectiveLabels(d, defaultLabels); err != nil {
+		return err
+	}
+
+	if err := validateClusterTopologyChange(d); err != nil {
+		return err
+	}
+
+	if d.NewValueKnown("replication_specs") && d.NewValueKnown("cluster_type") {
+		zones := d.Get("replication_specs").([]interface{})
+		if len(zones) > 1 && d.Get("cluster_type").(string) != "GEOSHARDED" {
+			return fmt.Errorf("replication_specs with %d zones requires cluster_type GEOSHARDED", len(zones))
+		}
+	}
+
+	if d.Get("bi_connector.0.read_preference").(string) == "analytics" && d.NewValueKnown("replication_spec") && d.NewValueKnown("replication_specs") {
+		// Only the configured format is planned, the other one still holds the previous state
+		regions := d.Get("replication_spec").(*schema.Set).List()
+		if d.HasChange("replication_specs") {
+			regions = []interface{}{}
+			for _, z := range d.Get("replication_specs").([]interface{}) {
+				regions = append(regions, z.(map[string]interface{})["regions_config"].(*schema.Set).List()...)
+			}
+		}
+		analyticsNodes := 0
+		for _, r := range regions {
+			analyticsNodes += r.(map[string]interface{})["analytics_nodes"].(int)
+		}
+		if analyticsNodes == 0 {
+			return errors.New("bi_connector read_preference analytics requires analytics_nodes in at least one replication_spec")
+		}
+	}
+
+	return nil
+}
+
+// validateClusterTopologyChange rejects changes Atlas can't apply to an existing cluster:
+// a sharded cluster can't become a replica set again, and replication_spec and num_shards
+// only describe clusters with a single zone.
+func validateClusterTopologyChange(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if o, n := d.GetChange("cluster_type"); d.NewValueKnown("cluster_type") && n.(string) == "REPLICASET" && (o.(string) == "SHARDED" || o.(string) == "GEOSHARDED") {
+		return fmt.Errorf("cluster_type can't be changed from %s to REPLICASET, Atlas can't turn a sharded cluster back into a replica set", o)
+	}
+
+	if d.HasChange("replication_spec") || d.HasChange("num_shards") {
+		oldZones, _ := d.GetChange("replication_specs")
+		if zones := len(oldZones.([]interface{})); zones > 1 {
+			return fmt.Errorf("replication_spec and num_shards can't describe a cluster with %d zones, use replication_specs", zones)
+		}
+	}
+	return nil
+}
+
+// planEffectiveLabels plans the labels of the cluster in Atlas, the configured labels merged
+// into the provider's default labels. Read leaves default labels out of labels, so default
+// labels added after the cluster was created only show up as a change of effective_labels.
+func planEffectiveLabels(d *schema.ResourceDiff, defaultLabels map[string]string) error {
+	if !d.NewValueKnown("labels") {
+		return d.SetNewComputed("effective_labels")
+	}
+
+	merged := map[string]interface{}{}
+	for _, l := range readLabelsFromSchema(d.Get("labels").(*schema.Set).List(), defaultLabels) {
+		merged[l.Key] = l.Value
+	}
+	if reflect.DeepEqual(merged, d.Get("effective_labels").(map[string]interface{})) {
+		return nil
+	}
+	return d.SetNew("effective_labels", merged)
+}
+
+// validateClusterReplacement forces a new cluster for provider changes other than tenant
+// upgrades, and refuses any replacement of a protected cluster, or of a cluster without
+// allow_replacement, since replacing a cluster deletes all of its data.
+func validateClusterReplacement(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	replacedBy := []string{}
+	for _, k := range []string{"name", "group"} {
+		if d.HasChange(k) {
+			replacedBy = append(replacedBy, k)
+		}
+	}
+	if !isTenantUpgrade(d) {
+		for _, k := range []string{"provider_name", "backing_provider"} {
+			if d.HasChange(k) {
+				if err := d.ForceNew(k); err != nil {
+					return err
+				}
+				replacedBy = append(replacedBy, k)
+			}
+		}
+	}
+	if len(replacedBy) == 0 {
+		return nil
+	}
+
+	// Protection is checked on both values, the destroy uses the current one
+	o, n := d.GetChange("termination_protection_enabled")
+	if o.(bool) || n.(bool) {
+		return fmt.Errorf("changing %s replaces the cluster, which termination_protection_enabled prevents", strings.Join(replacedBy, ", "))
+	}
+	if !d.Get("allow_replacement").(bool) {
+		return fmt.Errorf("changing %s replaces the cluster and deletes all of its data, set allow_replacement = true to allow it", strings.Join(replacedBy, ", "))
+	}
+	return nil
+}
+
+// isTenantUpgrade reports whether the diff moves a shared-tier cluster to a dedicated
+// cluster on its backing provider, which Atlas does in place.
+func isTenantUpgrade(d *schema.ResourceDiff) bool {
+	oldProvider, newProvider := d.GetChange("provider_name")
+	oldBackingProvider, _ := d.GetChange("backing_provider")
+	return oldProvider.(string) == "TENANT" && newProvider.(string) != "TENANT" && newProvider.(string) == oldBackingProvider.(string)
+}
+
+// validateTenantCluster rejects the settings shared-tier clusters don't support
+func validateTenantCluster(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("provider_name") {
+		return nil
+	}
+	if d.Get("provider_name").(string) != "TENANT" {
+		if d.NewValueKnown("backing_provider") && d.Get("backing_provider").(string) != "" {
+			return errors.New("backing_provider can only be set when provider_name is TENANT")
+		}
+		return nil
+	}
+
+	if d.NewValueKnown("backing_provider") && d.Get("backing_provider").(string) == "" {
+		return errors.New("backing_provider is required when provider_name is TENANT")
+	}
+	// Free-tier M0 clusters can't be created through the API, but existing ones can be managed
+	if size := d.Get("size").(string); d.NewValueKnown("size") && (d.HasChange("size") || d.HasChange("provider_name")) && size != "M2" && size != "M5" {
+		if size == "M0" {
+			return errors.New("M0 free-tier clusters can't be created through the Atlas API, and clusters can't be resized to M0")
+		}
+		return fmt.Errorf("shared-tier clusters only support the M0, M2 and M5 sizes, got %s. To upgrade to a dedicated cluster, also set provider_name to the backing_provider", size)
+	}
+	for _, k := range []string{"backup", "provider_backup", "auto_scaling_compute_enabled", "bi_connector.0.enabled"} {
+		if d.Get(k).(bool) {
+			return fmt.Errorf("%s is not supported on shared-tier clusters", k)
+		}
+	}
+	if d.Get("num_shards").(int) > 1 {
+		return errors.New("num_shards is not supported on shared-tier clusters")
+	}
+	return nil
+}
+
+// validateClusterCatalog checks the provider, instance sizes, regions, disk size and
+// MongoDB version against the cluster catalog. Values unknown at plan time are skipped,
+// and so are unchanged values of existing clusters, which Atlas accepted already.
+func validateClusterCatalog(d *schema.ResourceDiff, catalog *clusterCatalog) error {
+	if !d.NewValueKnown("provider_name") {
+		return nil
+	}
+	providerName := d.Get("provider_name").(string)
+	// Shared-tier clusters are deployed to the regions of their backing provider
+	regionProvider := providerName
+	if providerName == "TENANT" {
+		if !d.NewValueKnown("backing_provider") {
+			return nil
+		}
+		regionProvider = d.Get("backing_provider").(string)
+	}
+
+	sizeChanged := d.HasChange("size") || d.HasChange("provider_name")
+	size := d.Get("size").(string)
+	if d.NewValueKnown("size") {
+		if sizeChanged {
+			if err := catalog.validateInstanceSize(providerName, size); err != nil {
+				return err
+			}
+		}
+		if diskSize := d.Get("disk_size_gb").(float64); d.NewValueKnown("disk_size_gb") && diskSize > 0 && (sizeChanged || d.HasChange("disk_size_gb")) {
+			if err := catalog.validateDiskSize(providerName, size, diskSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, k := range []string{"provider_auto_scaling_compute_min_instance_size", "provider_auto_scaling_compute_max_instance_size"} {
+		if v := d.Get(k).(string); d.NewValueKnown(k) && v != "" && (d.HasChange(k) || d.HasChange("provider_name")) {
+			if err := catalog.validateInstanceSize(providerName, v); err != nil {
+				return fmt.Errorf("%s: %s", k, err)
+			}
+		}
+	}
+
+	regions := []string{}
+	if d.NewValueKnown("region") {
+		regions = append(regions, d.Get("region").(string))
+	}
+	if d.NewValueKnown("replication_spec") && d.NewValueKnown("replication_specs") {
+		regions = append(regions, clusterRegions(d.Get("replication_spec"), d.Get("replication_specs"))...)
+	}
+	// Regions the cluster is already deployed to are only checked when moving to another provider
+	existingRegions := map[string]bool{}
+	if !d.HasChange("provider_name") && !d.HasChange("backing_provider") {
+		oldRegion, _ := d.GetChange("region")
+		oldSpec, _ := d.GetChange("replication_spec")
+		oldSpecs, _ := d.GetChange("replication_specs")
+		for _, region := range append(clusterRegions(oldSpec, oldSpecs), oldRegion.(string)) {
+			existingRegions[region] = true
+		}
+	}
+	for _, region := range regions {
+		if region == "" || existingRegions[region] {
+			continue
+		}
+		if err := catalog.validateRegion(regionProvider, region); err != nil {
+			return err
+		}
+	}
+
+	if version := d.Get("mongodb_major_version").(string); d.NewValueKnown("mongodb_major_version") && version != "" && d.HasChange("mongodb_major_version") {
+		if err := catalog.validateMongoDBVersion(version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clusterRegions returns the regions of the replication_spec and replication_specs values
+func clusterRegions(replicationSpec, replicationSpecs interface{}) []string {
+	regions := []string{}
+	if spec, ok := replicationSpec.(*schema.Set); ok {
+		for _, r := range spec.List() {
+			regions = append(regions, r.(map[string]interface{})["region"].(string))
+		}
+	}
+	if specs, ok := replicationSpecs.([]interface{}); ok {
+		for _, z := range specs {
+			for _, r := range z.(map[string]interface{})["regions_config"].(*schema.Set).List() {
+				regions = append(regions, r.(map[string]interface{})["region"].(string))
+			}
+		}
+	}
+	return regions
+}
+
+// validateClusterStorage checks the volume type, IOPS and EBS encryption against the provider and instance size
+func validateClusterStorage(d *schema.ResourceDiff) error {
+	providerName := d.Get("provider_name").(string)
+	size := d.Get("size").(string)
+
+	if d.Get("provider_encrypt_ebs_volume").(bool) && providerName != "AWS" {
+		return errors.New("provider_encrypt_ebs_volume is only supported with provider_name AWS")
+	}
+
+	switch d.Get("provider_volume_type").(string) {
+	case "PROVISIONED":
+		if providerName != "AWS" {
+			return errors.New("provider_volume_type PROVISIONED is only supported with provider_name AWS")
+		}
+		if d.NewValueKnown("size") && instanceSizeNumber(size) < 30 {
+			return fmt.Errorf("provider_volume_type PROVISIONED requires an M30 or larger size, got %s", size)
+		}
+		iops := d.Get("provider_disk_iops").(int)
+		if iops < 100 {
+			return errors.New("provider_disk_iops of at least 100 is required when provider_volume_type is PROVISIONED")
+		}
+		// AWS allows at most 50 provisioned IOPS per GB
+		if diskSize := d.Get("disk_size_gb").(float64); d.NewValueKnown("disk_size_gb") && diskSize > 0 && float64(iops) > diskSize*50 {
+			return fmt.Errorf("provider_disk_iops can be at most %d for a disk_size_gb of %v", int(diskSize*50), diskSize)
+		}
+	case "STANDARD":
+		if d.HasChange("provider_disk_iops") {
+			if _, ok := d.GetOk("provider_disk_iops"); ok {
+				return errors.New("provider_disk_iops can only be set when provider_volume_type is PROVISIONED")
+			}
+		}
+	}
+
+	return nil
+}
+
+// instanceSizeNumber returns the numeric part of an instance size name, e.g. 30 for M30 or R40_NVME
+func instanceSizeNumber(size string) int {
+	n := 0
+	for _, r := range strings.TrimLeft(size, "MR") {
+		if r < '0' || r > '9' {
+			break
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
+
+// readLabelsFromSchema merges the configured labels into the provider's default labels
+func readLabelsFromSchema(labelsMap []interface{}, defaultLabels map[string]string) []ma.Label {
+	merged := map[string]string{}
+	for k, v := range defaultLabels {
+		merged[k] = v
+	}
+	for _, l := range labelsMap {
+		label := l.(map[string]interface{})
+		merged[label["key"].(string)] = label["value"].(string)
+	}
+
+	labels := []ma.Label{}
+	for k, v := range merged {
+		labels = append(labels, ma.Label{Key: k, Value: v})
+	}
+	return labels
+}
+
+// flattenLabels leaves out the labels coming from the provider's default labels,
+// unless they are also configured on the cluster
+func flattenLabels(labels []ma.Label, configured []interface{}, defaultLabels map[string]string) []map[string]interface{} {
+	configuredKeys := map[string]bool{}
+	for _, l := range configured {
+		configuredKeys[l.(map[string]interface{})["key"].(string)] = true
+	}
+
+	labelsMap := []map[string]interface{}{}
+	for _, l := range labels {
+		if v, ok := defaultLabels[l.Key]; ok && v == l.Value && !configuredKeys[l.Key] {
+			continue
+		}
+		labelsMap = append(labelsMap, map[string]interface{}{
+			"key":   l.Key,
+			"value": l.Value,
+		})
+	}
+	return labelsMap
+}
+
+func readBiConnectorFromSchema(d *schema.ResourceData) *ma.BiConnector {
+	return &ma.BiConnector{
+		Enabled:        d.Get("bi_connector.0.enabled").(bool),
+		ReadPreference: d.Get("bi_connector.0.read_preference").(string),
+	}
+}
+
+func readComputeAutoScalingFromSchema(d *schema.ResourceData) *ma.ComputeAutoScaling {
+	return &ma.ComputeAutoScaling{
+		Enabled:          d.Get("auto_scaling_compute_enabled").(bool),
+		ScaleDownEnabled: d.Get("auto_scaling_compute_scale_down_enabled").(bool),
+	}
+}
+
+func readProviderAutoScalingFromSchema(d *schema.ResourceData) *ma.ProviderAutoScaling {
+	return &ma.ProviderAutoScaling{
+		Compute: ma.ComputeInstanceSizes{
+			MinInstanceSize: d.Get("provider_auto_scaling_compute_min_instance_size").(string),
+			MaxInstanceSize: d.Get("provider_auto_scaling_compute_max_instance_size").(string),
+		},
+	}
+}
+
 func readReplicationSpecsFromSchema(replicationSpecs []interface{}) map[string]ma.ReplicationSpec {
 	specs := map[string]ma.ReplicationSpec{}
 	for _, r := range replicationSpecs {
@@ -489,3 +1394,259 @@ func readReplicationSpecsFromSchema(replicationSpecs []interface{}) map[string]m
 	}
 	return specs
 }
+
+func readZoneReplicationSpecsFromSchema(zones []interface{}) []ma.ZoneReplicationSpec {
+	specs := make([]ma.ZoneReplicationSpec, len(zones))
+	for i, z := range zones {
+		zone := z.(map[string]interface{})
+		specs[i] = ma.ZoneReplicationSpec{
+			ID:            zone["id"].(string),
+			ZoneName:      zone["zone_name"].(string),
+			NumShards:     zone["num_shards"].(int),
+			RegionsConfig: readReplicationSpecsFromSchema(zone["regions_config"].(*schema.Set).List()),
+		}
+	}
+	return specs
+}
+
+// zoneReplicationSpecsFromLegacy converts the legacy replicationSpec format to its
+// single zone, for clusters for which Atlas doesn't return replicationSpecs.
+func zoneReplicationSpecsFromLegacy(replicationSpec map[string]ma.ReplicationSpec, numShards int) []ma.ZoneReplicationSpec {
+	if len(replicationSpec) == 0 {
+		return []ma.ZoneReplicationSpec{}
+	}
+	return []ma.ZoneReplicationSpec{
+		{
+			ZoneName:      defaultZoneName,
+			NumShards:     numShards,
+			RegionsConfig: replicationSpec,
+		},
+	}
+}
+
+func flattenRegionsConfig(regionsConfig map[string]ma.ReplicationSpec) []interface{} {
+	regions := []interface{}{}
+	for region, replicationSpec := range regionsConfig {
+		regions = append(regions, map[string]interface{}{
+			"region":          region,
+			"priority":        replicationSpec.Priority,
+			"electable_nodes": replicationSpec.ElectableNodes,
+			"read_only_nodes": replicationSpec.ReadOnlyNodes,
+			"analytics_nodes": replicationSpec.AnalyticsNodes,
+		})
+	}
+	return regions
+}
+
+func flattenZoneReplicationSpecs(zones []ma.ZoneReplicationSpec) []interface{} {
+	specs := make([]interface{}, len(zones))
+	for i, z := range zones {
+		specs[i] = map[string]interface{}{
+			"id":             z.ID,
+			"zone_name":      z.ZoneName,
+			"num_shards":     z.NumShards,
+			"regions_config": flattenRegionsConfig(z.RegionsConfig),
+		}
+	}
+	return specs
+}
+
+// upgradeTenantCluster moves a shared-tier cluster to the dedicated instance size
+// of its new provider, and waits for Atlas to migrate the data.
+func upgradeTenantCluster(d *schema.ResourceData, client *ma.Client) error {
+	name := d.Get("name").(string)
+	group := d.Get("group").(string)
+
+	params := ma.Cluster{
+		Name: name,
+		ProviderSettings: ma.ProviderSettings{
+			ProviderName:     d.Get("provider_name").(string),
+			RegionName:       d.Get("region").(string),
+			InstanceSizeName: d.Get("size").(string),
+		},
+	}
+	_, _, err := client.Clusters.UpgradeTenant(group, &params)
+	if err != nil {
+		return fmt.Errorf("Error upgrading MongoDB Cluster %s to %s: %s", name, params.ProviderSettings.InstanceSizeName, err)
+	}
+
+	log.Println("[INFO] Waiting for MongoDB Cluster to be upgraded")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
+		Target:     []string{"IDLE"},
+		Refresh:    resourceClusterStateRefreshFunc(name, group, client),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	return err
+}
+
+// clearTenantUnsupportedFields removes the settings Atlas returns for shared-tier
+// clusters but rejects in their updates.
+func clearTenantUnsupportedFields(c *ma.Cluster) {
+	c.BackupEnabled = nil
+	c.ProviderBackupEnabled = nil
+	c.AutoScaling = nil
+	c.BiConnector = nil
+	c.ReplicationFactor = 0
+	c.ReplicationSpec = nil
+	c.ReplicationSpecs = nil
+	c.NumShards = 0
+	c.DiskSizeGB = 0
+	c.ClusterType = ""
+}
+
+func flattenConnectionStrings(connectionStrings *ma.ConnectionStrings) []interface{} {
+	if connectionStrings == nil {
+		return []interface{}{}
+	}
+
+	privateEndpoints := make([]interface{}, len(connectionStrings.PrivateEndpoint))
+	for i, p := range connectionStrings.PrivateEndpoint {
+		endpoints := make([]interface{}, len(p.Endpoints))
+		for j, e := range p.Endpoints {
+			endpoints[j] = map[string]interface{}{
+				"endpoint_id":   e.EndpointID,
+				"provider_name": e.ProviderName,
+				"region":        e.Region,
+			}
+		}
+		privateEndpoints[i] = map[string]interface{}{
+			"connection_string":     p.ConnectionString,
+			"srv_connection_string": p.SRVConnectionString,
+			"type":                  p.Type,
+			"endpoints":             endpoints,
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"standard":             connectionStrings.Standard,
+			"standard_srv":         connectionStrings.StandardSrv,
+			"private":              connectionStrings.Private,
+			"private_srv":          connectionStrings.PrivateSrv,
+			"aws_private_link":     connectionStrings.AwsPrivateLink,
+			"aws_private_link_srv": connectionStrings.AwsPrivateLinkSrv,
+			"private_endpoint":     privateEndpoints,
+		},
+	}
+}
+
+// parseMongoURIHosts returns the host:port pairs of a mongodb:// URI, e.g.
+// mongodb://cluster0-shard-00-00.mongodb.net:27017,cluster0-shard-00-01.mongodb.net:27017
+func parseMongoURIHosts(uri string) []string {
+	hosts := []string{}
+	if !strings.HasPrefix(uri, "mongodb://") {
+		return hosts
+	}
+
+	hostList := strings.TrimPrefix(uri, "mongodb://")
+	if i := strings.LastIndex(hostList, "@"); i >= 0 {
+		hostList = hostList[i+1:]
+	}
+	if i := strings.IndexAny(hostList, "/?"); i >= 0 {
+		hostList = hostList[:i]
+	}
+	for _, h := range strings.Split(hostList, ",") {
+		if h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// parseReplicaSetName returns the replicaSet option of a connection string, which
+// sharded clusters don't have.
+func parseReplicaSetName(uri string) string {
+	i := strings.Index(uri, "?")
+	if i < 0 {
+		return ""
+	}
+	options, err := url.ParseQuery(uri[i+1:])
+	if err != nil {
+		return ""
+	}
+	return options.Get("replicaSet")
+}
+
+// updateClusterProcessArgs sends the advanced_configuration block to Atlas and waits
+// for the rolling restart of the cluster to finish.
+func updateClusterProcessArgs(d *schema.ResourceData, client *ma.Client, timeout time.Duration) error {
+	name := d.Get("name").(string)
+	group := d.Get("group").(string)
+
+	_, _, err := client.Clusters.UpdateProcessArgs(group, name, readProcessArgsFromSchema(d))
+	if err != nil {
+		return fmt.Errorf("Error updating advanced configuration of MongoDB Cluster %s: %s", name, err)
+	}
+
+	log.Println("[INFO] Waiting for MongoDB Cluster advanced configuration to be applied")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
+		Target:     []string{"IDLE"},
+		Refresh:    resourceClusterStateRefreshFunc(name, group, client),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	return err
+}
+
+func readProcessArgsFromSchema(d *schema.ResourceData) *ma.ProcessArgs {
+	p := &ma.ProcessArgs{
+		MinimumEnabledTLSProtocol: d.Get("advanced_configuration.0.minimum_enabled_tls_protocol").(string),
+	}
+	// Only send the options which are set, Atlas keeps its defaults for the others
+	if v, ok := d.GetOkExists("advanced_configuration.0.fail_index_key_too_long"); ok {
+		failIndexKeyTooLong := v.(bool)
+		p.FailIndexKeyTooLong = &failIndexKeyTooLong
+	}
+	if v, ok := d.GetOkExists("advanced_configuration.0.javascript_enabled"); ok {
+		javascriptEnabled := v.(bool)
+		p.JavascriptEnabled = &javascriptEnabled
+	}
+	if v, ok := d.GetOkExists("advanced_configuration.0.no_table_scan"); ok {
+		noTableScan := v.(bool)
+		p.NoTableScan = &noTableScan
+	}
+	if v, ok := d.GetOk("advanced_configuration.0.oplog_size_mb"); ok {
+		oplogSizeMB := v.(int)
+		p.OplogSizeMB = &oplogSizeMB
+	}
+	if v, ok := d.GetOk("advanced_configuration.0.sample_size_bi_connector"); ok {
+		sampleSizeBIConnector := v.(int)
+		p.SampleSizeBIConnector = &sampleSizeBIConnector
+	}
+	return p
+}
+
+func flattenProcessArgs(p *ma.ProcessArgs) []interface{} {
+	advancedConfiguration := map[string]interface{}{
+		"minimum_enabled_tls_protocol": p.MinimumEnabledTLSProtocol,
+	}
+	if p.FailIndexKeyTooLong != nil {
+		advancedConfiguration["fail_index_key_too_long"] = *p.FailIndexKeyTooLong
+	}
+	if p.JavascriptEnabled != nil {
+		advancedConfiguration["javascript_enabled"] = *p.JavascriptEnabled
+	}
+	if p.NoTableScan != nil {
+		advancedConfiguration["no_table_scan"] = *p.NoTableScan
+	}
+	if p.OplogSizeMB != nil {
+		advancedConfiguration["oplog_size_mb"] = *p.OplogSizeMB
+	}
+	if p.SampleSizeBIConnector != nil {
+		advancedConfiguration["sample_size_bi_connector"] = *p.SampleSizeBIConnector
+	}
+	return []interface{}{advancedConfiguration}
+}
@@ -0,0 +1,128 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// clusterFailoverStartTimeout is how long to wait for a cluster to leave IDLE after
+// its primaries were restarted.
+const clusterFailoverStartTimeout = 5 * time.Minute
+
+// resourceClusterFailoverTest restarts the primaries of a cluster when it is created,
+// or replaced after a change of its triggers. The file isn't named after the resource
+// since Go would take a _test.go file for tests.
+func resourceClusterFailoverTest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterFailoverTestCreate,
+		Read:   resourceClusterFailoverTestRead,
+		Delete: resourceClusterFailoverTestDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceClusterFailoverTestCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	c, _, err := client.Clusters.Get(group, clusterName)
+	if err != nil {
+		return fmt.Errorf("Error reading MongoDB Cluster %s: %s", clusterName, err)
+	}
+
+	_, err = client.Clusters.RestartPrimaries(group, clusterName)
+	if err != nil {
+		return fmt.Errorf("Error restarting the primaries of MongoDB Cluster %s: %s", clusterName, err)
+	}
+	d.SetId(c.ID)
+	log.Printf("[INFO] MongoDB Cluster Failover Test ID: %s", d.Id())
+
+	log.Println("[INFO] Waiting for MongoDB Cluster to start failing over")
+
+	// The cluster is still IDLE right after the restart was requested, so wait for
+	// the failover to start before waiting for it to end
+	startConf := &resource.StateChangeConf{
+		Pending:    []string{"IDLE"},
+		Target:     []string{"UPDATING", "REPAIRING"},
+		Refresh:    resourceClusterStateRefreshFunc(clusterName, group, client),
+		Timeout:    clusterFailoverStartTimeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err = startConf.WaitForState()
+	if err != nil {
+		if _, ok := err.(*resource.TimeoutError); !ok {
+			return err
+		}
+		// The failover can be over before it was ever seen
+		log.Printf("[WARN] MongoDB Cluster %s didn't leave IDLE while failing over", clusterName)
+	}
+
+	log.Println("[INFO] Waiting for MongoDB Cluster to fail over")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
+		Target:     []string{"IDLE"},
+		Refresh:    resourceClusterStateRefreshFunc(clusterName, group, client),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 10 * time.Second,
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	return resourceClusterFailoverTestRead(d, meta)
+}
+
+func resourceClusterFailoverTestRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+
+	_, resp, err := client.Clusters.Get(d.Get("group").(string), d.Get("cluster_name").(string))
+	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Cluster %s not found, removing failover test from state", d.Get("cluster_name").(string))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading MongoDB Cluster %s: %s", d.Get("cluster_name").(string), err)
+	}
+
+	return nil
+}
+
+// A failover can't be undone, so destroying the resource only removes it from the state
+func resourceClusterFailoverTestDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] MongoDB Cluster Failover Test destroy: %v", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,135 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMongodbatlasClusterFailoverTest_basic(t *testing.T) {
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster_failover_test.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterFailoverTest(projectName, clusterName, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterFailoverTestIdle(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "cluster_name", clusterName),
+					resource.TestCheckResourceAttr(resourceName, "triggers.drill", "1"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterFailoverTest(projectName, clusterName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterFailoverTestIdle(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "triggers.drill", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMongodbatlasClusterFailoverTestIdle(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Cluster Failover Test ID is set")
+		}
+
+		client := testAccProvider.Meta().(*MongoDBClient).Client
+
+		c, _, err := client.Clusters.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["cluster_name"])
+		if err != nil {
+			return err
+		}
+
+		if c.StateName != "IDLE" {
+			return fmt.Errorf("Cluster %q is %s after the failover test", c.Name, c.StateName)
+		}
+		return nil
+	}
+}
+
+func testAccMongodbatlasClusterFailoverTest(projectName, clusterName, drill string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster_failover_test" "test" {
+  group = "${data.mongodbatlas_project.test.id}"
+  cluster_name = "${mongodbatlas_cluster.test.name}"
+
+  triggers = {
+    drill = "%s"
+  }
+}
+
+resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, drill, clusterName, projectName)
+}
+
+func TestMongodbatlasClusterFailoverTest_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceClusterFailoverTest(), map[string]interface{}{
+		"group":        "5ba8c5c396e8211ae8272486",
+		"cluster_name": "test",
+	}, "5ba8c5c396e8211ae8272487")
+}
+
+func TestMongodbatlasClusterFailoverTest_waitForFailover(t *testing.T) {
+	// The cluster stays IDLE for a moment after the restart was requested
+	states := []string{"IDLE", "IDLE", "UPDATING", "IDLE", "IDLE"}
+	gets := 0
+	httpClient := &http.Client{Transport: testRoundTripper(func(req *http.Request) *http.Response {
+		body := `{}`
+		if req.Method == http.MethodGet {
+			body = fmt.Sprintf(`{"id":"5ba8c5c396e8211ae8272487","name":"test","groupId":"5ba8c5c396e8211ae8272486","stateName":"%s"}`, states[gets])
+			gets++
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}
+	})}
+	meta := &MongoDBClient{Client: ma.NewClient(httpClient)}
+
+	d := schema.TestResourceDataRaw(t, resourceClusterFailoverTest().Schema, map[string]interface{}{
+		"group":        "5ba8c5c396e8211ae8272486",
+		"cluster_name": "test",
+	})
+	if err := resourceClusterFailoverTestCreate(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if gets != len(states) {
+		t.Fatalf("expected the cluster to be read %d times, got %d", len(states), gets)
+	}
+}
@@ -0,0 +1,78 @@
+package mongodbatlas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testResourceClusterResourceV0_replicaSet() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         "5b9a7e2c96e82136a1c86bd7",
+		"identifier": "5b9a7e2c96e82136a1c86bd7",
+		"group":      "812nf72jf82j72j72hejw8yr",
+		"name":       "cluster",
+		"num_shards": float64(1),
+		"replication_spec": []interface{}{
+			map[string]interface{}{
+				"region":          "US_EAST_1",
+				"priority":        float64(7),
+				"electable_nodes": float64(3),
+				"read_only_nodes": float64(0),
+				"analytics_nodes": float64(1),
+			},
+		},
+	}
+}
+
+func testResourceClusterResourceV1_replicaSet() map[string]interface{} {
+	v0 := testResourceClusterResourceV0_replicaSet()
+	return map[string]interface{}{
+		"id":               v0["id"],
+		"identifier":       v0["identifier"],
+		"group":            v0["group"],
+		"name":             v0["name"],
+		"num_shards":       v0["num_shards"],
+		"replication_spec": v0["replication_spec"],
+		"replication_specs": []interface{}{
+			map[string]interface{}{
+				"id":             "",
+				"zone_name":      "Zone 1",
+				"num_shards":     v0["num_shards"],
+				"regions_config": v0["replication_spec"],
+			},
+		},
+	}
+}
+
+func testResourceClusterResourceV0_noReplicationSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"id":               "5b9a7e2c96e82136a1c86bd7",
+		"group":            "812nf72jf82j72j72hejw8yr",
+		"name":             "cluster",
+		"replication_spec": []interface{}{},
+	}
+}
+
+func TestResourceClusterStateUpgradeV0_replicaSet(t *testing.T) {
+	expected := testResourceClusterResourceV1_replicaSet()
+	actual, err := resourceClusterStateUpgradeV0(testResourceClusterResourceV0_replicaSet(), nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
+func TestResourceClusterStateUpgradeV0_noReplicationSpec(t *testing.T) {
+	expected := testResourceClusterResourceV0_noReplicationSpec()
+	actual, err := resourceClusterStateUpgradeV0(testResourceClusterResourceV0_noReplicationSpec(), nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
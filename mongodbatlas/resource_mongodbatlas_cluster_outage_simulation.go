@@ -0,0 +1,224 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceClusterOutageSimulation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterOutageSimulationCreate,
+		Read:   resourceClusterOutageSimulationRead,
+		Delete: resourceClusterOutageSimulationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(25 * time.Minute),
+			Delete: schema.DefaultTimeout(25 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"outage_filters": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloud_provider": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"AWS", "GCP", "AZURE"}, false),
+						},
+						"region_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Default:  "REGION",
+						},
+					},
+				},
+			},
+			"start_request_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceClusterOutageSimulationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	params := ma.OutageSimulation{
+		OutageFilters: readOutageFiltersFromSchema(d.Get("outage_filters").([]interface{})),
+	}
+
+	simulation, _, err := client.OutageSimulations.Start(group, clusterName, &params)
+	if err != nil {
+		return fmt.Errorf("Error starting outage simulation of MongoDB Cluster %s: %s", clusterName, err)
+	}
+	d.SetId(simulation.ID)
+	log.Printf("[INFO] MongoDB Cluster Outage Simulation ID: %s", d.Id())
+
+	log.Println("[INFO] Waiting for MongoDB Cluster Outage Simulation to start")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"START_REQUESTED", "STARTING"},
+		Target:     []string{"SIMULATING"},
+		Refresh:    resourceClusterOutageSimulationStateRefreshFunc(clusterName, group, client),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	return resourceClusterOutageSimulationRead(d, meta)
+}
+
+func resourceClusterOutageSimulationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	clusterName := d.Get("cluster_name").(string)
+
+	simulation, resp, err := client.OutageSimulations.Get(d.Get("group").(string), clusterName)
+	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Cluster Outage Simulation %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading outage simulation of MongoDB Cluster %s: %s", clusterName, err)
+	}
+
+	// Another simulation may have been started on the cluster since this one ended
+	if simulation.ID != d.Id() || simulation.State == "COMPLETE" {
+		log.Printf("[WARN] MongoDB Cluster Outage Simulation %s has ended, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("outage_filters", flattenOutageFilters(simulation.OutageFilters)); err != nil {
+		log.Printf("[WARN] Error setting outage_filters for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("start_request_date", simulation.StartRequestDate); err != nil {
+		log.Printf("[WARN] Error setting start_request_date for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("state", simulation.State); err != nil {
+		log.Printf("[WARN] Error setting state for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceClusterOutageSimulationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	log.Printf("[DEBUG] MongoDB Cluster Outage Simulation destroy: %v", d.Id())
+	_, resp, err := client.OutageSimulations.End(group, clusterName)
+	if err != nil {
+		// The simulation already ended
+		if isNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error ending outage simulation of MongoDB Cluster %s: %s", clusterName, err)
+	}
+
+	log.Println("[INFO] Waiting for MongoDB Cluster Outage Simulation to end")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"SIMULATING", "RECOVERY_REQUESTED", "RECOVERING"},
+		Target:     []string{"COMPLETE"},
+		Refresh:    resourceClusterOutageSimulationStateRefreshFunc(clusterName, group, client),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resourceClusterOutageSimulationStateRefreshFunc reports an ended simulation as COMPLETE,
+// since Atlas forgets about it once the cluster has recovered.
+func resourceClusterOutageSimulationStateRefreshFunc(clusterName, group string, client *ma.Client) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		simulation, resp, err := client.OutageSimulations.Get(group, clusterName)
+		if err != nil {
+			if isNotFound(resp) {
+				return 42, "COMPLETE", nil
+			}
+			log.Printf("Error reading outage simulation of MongoDB Cluster %s: %s", clusterName, err)
+			return nil, "", err
+		}
+
+		if simulation.State != "" {
+			log.Printf("[DEBUG] MongoDB Cluster Outage Simulation status for cluster: %s: %s", clusterName, simulation.State)
+		}
+
+		return simulation, simulation.State, nil
+	}
+}
+
+func readOutageFiltersFromSchema(filters []interface{}) []ma.OutageFilter {
+	outageFilters := make([]ma.OutageFilter, len(filters))
+	for i, f := range filters {
+		filter := f.(map[string]interface{})
+		outageFilters[i] = ma.OutageFilter{
+			CloudProvider: filter["cloud_provider"].(string),
+			RegionName:    filter["region_name"].(string),
+			Type:          filter["type"].(string),
+		}
+	}
+	return outageFilters
+}
+
+func flattenOutageFilters(outageFilters []ma.OutageFilter) []map[string]interface{} {
+	filters := make([]map[string]interface{}, 0, len(outageFilters))
+	for _, f := range outageFilters {
+		filters = append(filters, map[string]interface{}{
+			"cloud_provider": f.CloudProvider,
+			"region_name":    f.RegionName,
+			"type":           f.Type,
+		})
+	}
+	return filters
+}
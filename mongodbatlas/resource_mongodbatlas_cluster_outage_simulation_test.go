@@ -0,0 +1,152 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMongodbatlasClusterOutageSimulation_basic(t *testing.T) {
+	var simulation ma.OutageSimulation
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster_outage_simulation.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterOutageSimulationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterOutageSimulation(projectName, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterOutageSimulationExists(resourceName, &simulation),
+					resource.TestCheckResourceAttr(resourceName, "cluster_name", clusterName),
+					resource.TestCheckResourceAttr(resourceName, "state", "SIMULATING"),
+					resource.TestCheckResourceAttr(resourceName, "outage_filters.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "outage_filters.0.cloud_provider", "AWS"),
+					resource.TestCheckResourceAttr(resourceName, "outage_filters.0.region_name", "US_EAST_1"),
+					resource.TestCheckResourceAttr(resourceName, "outage_filters.0.type", "REGION"),
+					resource.TestCheckResourceAttrSet(resourceName, "start_request_date"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMongodbatlasClusterOutageSimulationExists(n string, res *ma.OutageSimulation) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Cluster Outage Simulation ID is set")
+		}
+
+		client := testAccProvider.Meta().(*MongoDBClient).Client
+
+		simulation, _, err := client.OutageSimulations.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["cluster_name"])
+		if err != nil {
+			return err
+		}
+
+		if simulation.ID != rs.Primary.ID {
+			return fmt.Errorf("Cluster Outage Simulation (%s) not found", rs.Primary.ID)
+		}
+
+		*res = *simulation
+		return nil
+	}
+}
+
+func testAccCheckMongodbatlasClusterOutageSimulationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*MongoDBClient).Client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodbatlas_cluster_outage_simulation" {
+			continue
+		}
+
+		// Try to find the simulation
+		simulation, resp, err := client.OutageSimulations.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["cluster_name"])
+
+		if err == nil && simulation.ID == rs.Primary.ID && simulation.State != "COMPLETE" {
+			return fmt.Errorf("Cluster Outage Simulation (%s) still exists", rs.Primary.ID)
+		}
+
+		if err != nil && resp.StatusCode != 404 {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccMongodbatlasClusterOutageSimulation(projectName, clusterName string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster_outage_simulation" "test" {
+  group = "${data.mongodbatlas_project.test.id}"
+  cluster_name = "${mongodbatlas_cluster.test.name}"
+
+  outage_filters {
+    cloud_provider = "AWS"
+    region_name = "US_EAST_1"
+  }
+}
+
+resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+
+  replication_spec {
+    region = "US_EAST_1"
+    priority = 7
+    electable_nodes = 3
+  }
+
+  replication_spec {
+    region = "US_EAST_2"
+    priority = 6
+    electable_nodes = 2
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, projectName)
+}
+
+func TestMongodbatlasClusterOutageSimulation_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceClusterOutageSimulation(), map[string]interface{}{
+		"group":        "5ba8c5c396e8211ae8272486",
+		"cluster_name": "test",
+	}, "5ba8c5c396e8211ae8272487")
+}
+
+func TestMongodbatlasClusterOutageSimulation_deleteEnded(t *testing.T) {
+	r := resourceClusterOutageSimulation()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group":        "5ba8c5c396e8211ae8272486",
+		"cluster_name": "test",
+	})
+	d.SetId("5ba8c5c396e8211ae8272488")
+	meta := testOfflineClient(http.StatusNotFound, `{"detail":"Not found.","error":404,"errorCode":"RESOURCE_NOT_FOUND","reason":"Not Found"}`)
+	if err := r.Delete(d, meta); err != nil {
+		t.Fatalf("expected no error when the simulation already ended, got %s", err)
+	}
+}
@@ -3,11 +3,17 @@ package mongodbatlas
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -36,6 +42,10 @@ func TestAccMongodbatlasCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "mongo_uri"),
 					resource.TestCheckResourceAttrSet(resourceName, "mongo_uri_updated"),
 					resource.TestCheckResourceAttrSet(resourceName, "mongo_uri_with_options"),
+					resource.TestCheckResourceAttrSet(resourceName, "connection_strings.0.standard"),
+					resource.TestCheckResourceAttrSet(resourceName, "connection_strings.0.standard_srv"),
+					resource.TestCheckResourceAttrSet(resourceName, "replica_set_name"),
+					resource.TestCheckResourceAttr(resourceName, "hosts.#", "3"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
 					resource.TestCheckResourceAttr(resourceName, "mongodb_major_version", "3.6"),
@@ -70,6 +80,568 @@ func TestAccMongodbatlasCluster_basic(t *testing.T) {
 	})
 }
 
+func TestAccMongodbatlasCluster_advancedConfiguration(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterAdvancedConfiguration(projectName, clusterName, "false", "TLS1_1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "advanced_configuration.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "advanced_configuration.0.javascript_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "advanced_configuration.0.minimum_enabled_tls_protocol", "TLS1_1"),
+					resource.TestCheckResourceAttrSet(resourceName, "advanced_configuration.0.no_table_scan"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterAdvancedConfiguration(projectName, clusterName, "true", "TLS1_2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "advanced_configuration.0.javascript_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "advanced_configuration.0.minimum_enabled_tls_protocol", "TLS1_2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMongodbatlasCluster_autoScalingCompute(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterAutoScalingCompute(projectName, clusterName, "M30"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "auto_scaling_compute_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "auto_scaling_compute_scale_down_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "provider_auto_scaling_compute_min_instance_size", "M10"),
+					resource.TestCheckResourceAttr(resourceName, "provider_auto_scaling_compute_max_instance_size", "M30"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterAutoScalingCompute(projectName, clusterName, "M40"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "provider_auto_scaling_compute_max_instance_size", "M40"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMongodbatlasCluster_biConnector(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterBiConnector(projectName, clusterName, "true", "secondary"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "bi_connector.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "bi_connector.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "bi_connector.0.read_preference", "secondary"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterBiConnector(projectName, clusterName, "false", "primary"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "bi_connector.0.enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "bi_connector.0.read_preference", "primary"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMongodbatlasCluster_globalCluster(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterGlobalCluster(projectName, clusterName, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "cluster_type", "GEOSHARDED"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "replication_specs.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.zone_name", "Zone 1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.num_shards", "1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.regions_config.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.1.zone_name", "Zone 2"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterGlobalCluster(projectName, clusterName, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.num_shards", "2"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.1.num_shards", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMongodbatlasCluster_labels(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterLabels(projectName, clusterName, "data"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "2"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterLabels(projectName, clusterName, "platform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestMongodbatlasCluster_labels(t *testing.T) {
+	defaultLabels := map[string]string{"owner": "data", "environment": "production"}
+	configured := []interface{}{
+		map[string]interface{}{"key": "owner", "value": "platform"},
+		map[string]interface{}{"key": "environment", "value": "production"},
+	}
+	labels := []ma.Label{
+		{Key: "owner", Value: "platform"},
+		{Key: "environment", Value: "production"},
+		{Key: "cost-center", Value: "1234"},
+	}
+
+	expected := []map[string]interface{}{
+		{"key": "owner", "value": "platform"},
+		{"key": "environment", "value": "production"},
+		{"key": "cost-center", "value": "1234"},
+	}
+	if actual := flattenLabels(labels, configured, defaultLabels); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+
+	expected = []map[string]interface{}{
+		{"key": "owner", "value": "platform"},
+		{"key": "cost-center", "value": "1234"},
+	}
+	if actual := flattenLabels(labels, configured[:1], defaultLabels); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+
+	merged := readLabelsFromSchema(configured[:1], defaultLabels)
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 labels, got %#v", merged)
+	}
+	for _, l := range merged {
+		if l.Key == "owner" && l.Value != "platform" {
+			t.Fatalf("expected the owner label to be overridden, got %#v", l)
+		}
+	}
+}
+
+func TestMongodbatlasCluster_freeTierCluster(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":                  "test",
+		"group":                 "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version": "4.4",
+		"backup":                false,
+		"size":                  "M0",
+		"provider_name":         "TENANT",
+		"backing_provider":      "AWS",
+		"region":                "US_EAST_1",
+	}
+	attributes := map[string]string{
+		"id":                    "5ba8c5c396e8211ae8272487",
+		"name":                  "test",
+		"group":                 "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version": "4.4",
+		"backup":                "false",
+		"size":                  "M0",
+		"provider_name":         "TENANT",
+		"backing_provider":      "AWS",
+		"region":                "US_EAST_1",
+	}
+	meta := &MongoDBClient{ClusterCatalog: defaultClusterCatalog()}
+
+	if _, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta); err != nil {
+		t.Fatalf("expected an existing M0 cluster to plan, got %s", err)
+	}
+
+	if _, err := testResourceDiff(t, resourceCluster(), "", nil, raw, meta); err == nil {
+		t.Fatal("expected a new M0 cluster to fail")
+	}
+
+	raw["size"] = "M2"
+	if _, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta); err != nil {
+		t.Fatalf("expected an upgrade of an M0 cluster to M2 to plan, got %s", err)
+	}
+}
+
+func TestMongodbatlasCluster_updateOmittedLabels(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":                           "test",
+		"group":                          "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version":          "4.4",
+		"backup":                         false,
+		"size":                           "M10",
+		"provider_name":                  "AWS",
+		"region":                         "US_EAST_1",
+		"termination_protection_enabled": true,
+	}
+	state := &terraform.InstanceState{
+		ID: "5ba8c5c396e8211ae8272487",
+		Attributes: map[string]string{
+			"id":                             "5ba8c5c396e8211ae8272487",
+			"name":                           "test",
+			"group":                          "5ba8c5c396e8211ae8272486",
+			"mongodb_major_version":          "4.4",
+			"backup":                         "false",
+			"provider_backup":                "false",
+			"size":                           "M10",
+			"provider_name":                  "AWS",
+			"region":                         "US_EAST_1",
+			"replication_factor":             "3",
+			"paused":                         "false",
+			"termination_protection_enabled": "false",
+			"allow_replacement":              "false",
+			"disk_gb_enabled":                "true",
+			"auto_scaling_compute_enabled":   "false",
+			"auto_scaling_compute_scale_down_enabled": "false",
+		},
+	}
+
+	// Atlas leaves the labels out of clusters without them
+	cluster := `{"id":"5ba8c5c396e8211ae8272487","name":"test","groupId":"5ba8c5c396e8211ae8272486","mongoDBMajorVersion":"4.4","providerSettings":{"providerName":"AWS","instanceSizeName":"M10","regionName":"US_EAST_1"},"stateName":"IDLE"}`
+	var updateBody string
+	httpClient := &http.Client{Transport: testRoundTripper(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(req.Body)
+			updateBody = string(body)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(cluster)),
+			Request:    req,
+		}
+	})}
+	meta := &MongoDBClient{Client: ma.NewClient(httpClient), ClusterCatalog: defaultClusterCatalog()}
+
+	r := resourceCluster()
+	diff, err := testResourceDiff(t, r, state.ID, state.Attributes, raw, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Apply(state, diff, meta); err != nil {
+		t.Fatal(err)
+	}
+	if updateBody == "" {
+		t.Fatal("expected the cluster to be updated")
+	}
+	if strings.Contains(updateBody, `"labels":null`) {
+		t.Fatalf("expected labels not to be sent as null, got %s", updateBody)
+	}
+}
+
+func TestMongodbatlasCluster_newDefaultLabels(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":                  "test",
+		"group":                 "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version": "4.4",
+		"backup":                false,
+		"size":                  "M10",
+		"provider_name":         "AWS",
+		"region":                "US_EAST_1",
+		"labels": []interface{}{
+			map[string]interface{}{"key": "owner", "value": "platform"},
+		},
+	}
+	owner := schema.HashResource(resourceCluster().Schema["labels"].Elem.(*schema.Resource))(map[string]interface{}{"key": "owner", "value": "platform"})
+	attributes := map[string]string{
+		"id":                     "5ba8c5c396e8211ae8272487",
+		"name":                   "test",
+		"group":                  "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version":  "4.4",
+		"backup":                 "false",
+		"size":                   "M10",
+		"provider_name":          "AWS",
+		"region":                 "US_EAST_1",
+		"labels.#":               "1",
+		"effective_labels.%":     "1",
+		"effective_labels.owner": "platform",
+	}
+
+	attributes[fmt.Sprintf("labels.%d.key", owner)] = "owner"
+	attributes[fmt.Sprintf("labels.%d.value", owner)] = "platform"
+
+	meta := &MongoDBClient{ClusterCatalog: defaultClusterCatalog()}
+	diff, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := diff.Attributes["effective_labels.owner"]; ok {
+		t.Fatalf("expected no change of effective_labels without default labels, got %#v", diff.Attributes)
+	}
+
+	meta.DefaultLabels = map[string]string{"owner": "data", "environment": "production"}
+	diff, err = testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, ok := diff.Attributes["effective_labels.environment"]; !ok || a.New != "production" {
+		t.Fatalf("expected the new default label to be planned, got %#v", diff.Attributes)
+	}
+	if _, ok := diff.Attributes["effective_labels.owner"]; ok {
+		t.Fatalf("expected the configured owner label to take precedence, got %#v", diff.Attributes)
+	}
+}
+
+func TestAccMongodbatlasCluster_provisionedIOPS(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterProvisionedIOPS(projectName, clusterName, "40"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "provider_volume_type", "PROVISIONED"),
+					resource.TestCheckResourceAttr(resourceName, "provider_disk_iops", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "provider_encrypt_ebs_volume", "true"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasClusterProvisionedIOPS(projectName, clusterName, "60"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "60"),
+					resource.TestCheckResourceAttr(resourceName, "provider_disk_iops", "1000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMongodbatlasCluster_tenantUpgrade(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterTenant(projectName, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "provider_name", "TENANT"),
+					resource.TestCheckResourceAttr(resourceName, "backing_provider", "AWS"),
+					resource.TestCheckResourceAttr(resourceName, "size", "M2"),
+					resource.TestCheckResourceAttr(resourceName, "backup", "false"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasCluster(projectName, clusterName, "M10", "10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					testAccCheckMongodbatlasClusterIdentifier(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "provider_name", "AWS"),
+					resource.TestCheckResourceAttr(resourceName, "backing_provider", ""),
+					resource.TestCheckResourceAttr(resourceName, "size", "M10"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckMongodbatlasClusterIdentifier checks that the cluster was updated in place
+func testAccCheckMongodbatlasClusterIdentifier(n string, res *ma.Cluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID != res.ID {
+			return fmt.Errorf("Cluster was replaced, expected ID %s, got %s", res.ID, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func TestMongodbatlasCluster_connectionStringParsing(t *testing.T) {
+	uri := "mongodb://cluster0-shard-00-00-abcde.mongodb.net:27017,cluster0-shard-00-01-abcde.mongodb.net:27017,cluster0-shard-00-02-abcde.mongodb.net:27017"
+	expected := []string{
+		"cluster0-shard-00-00-abcde.mongodb.net:27017",
+		"cluster0-shard-00-01-abcde.mongodb.net:27017",
+		"cluster0-shard-00-02-abcde.mongodb.net:27017",
+	}
+
+	for _, u := range []string{uri, "mongodb://user:pass@" + uri[len("mongodb://"):] + "/admin?ssl=true"} {
+		if hosts := parseMongoURIHosts(u); !reflect.DeepEqual(expected, hosts) {
+			t.Errorf("%s: expected hosts %v, got %v", u, expected, hosts)
+		}
+	}
+	if hosts := parseMongoURIHosts("mongodb+srv://cluster0-abcde.mongodb.net"); len(hosts) != 0 {
+		t.Errorf("expected no hosts for an SRV URI, got %v", hosts)
+	}
+
+	if name := parseReplicaSetName(uri + "/?ssl=true&authSource=admin&replicaSet=Cluster0-shard-0"); name != "Cluster0-shard-0" {
+		t.Errorf("expected replica set name Cluster0-shard-0, got %q", name)
+	}
+	if name := parseReplicaSetName(uri + "/?ssl=true&authSource=admin"); name != "" {
+		t.Errorf("expected no replica set name for a sharded cluster, got %q", name)
+	}
+}
+
+func TestAccMongodbatlasCluster_terminationProtection(t *testing.T) {
+	var cluster ma.Cluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasClusterTerminationProtection(projectName, clusterName, "true", "false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "termination_protection_enabled", "true"),
+				),
+			},
+			{
+				Config:      testAccMongodbatlasClusterTerminationProtection(projectName, clusterName+"-new", "true", "true"),
+				ExpectError: regexp.MustCompile("termination_protection_enabled prevents"),
+			},
+			{
+				Config:      testAccMongodbatlasClusterTerminationProtection(projectName, clusterName+"-new", "false", "false"),
+				ExpectError: regexp.MustCompile("set allow_replacement = true"),
+			},
+			{
+				Config: testAccMongodbatlasClusterTerminationProtection(projectName, clusterName, "false", "false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasClusterExists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "termination_protection_enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestMongodbatlasCluster_instanceSizeNumber(t *testing.T) {
+	cases := map[string]int{
+		"M2":       2,
+		"M10":      10,
+		"M30":      30,
+		"R40":      40,
+		"M40_NVME": 40,
+		"":         0,
+	}
+	for size, expected := range cases {
+		if actual := instanceSizeNumber(size); actual != expected {
+			t.Fatalf("expected %d for %q, got %d", expected, size, actual)
+		}
+	}
+}
+
+func TestMongodbatlasCluster_catalogExistingCluster(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":                  "test",
+		"group":                 "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version": "4.4",
+		"backup":                false,
+		"size":                  "M10",
+		"provider_name":         "AWS",
+		"region":                "AP_NORTHEAST_3",
+	}
+	attributes := map[string]string{
+		"id":                    "5ba8c5c396e8211ae8272487",
+		"name":                  "test",
+		"group":                 "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version": "4.4",
+		"backup":                "false",
+		"size":                  "M10",
+		"provider_name":         "AWS",
+		"region":                "AP_NORTHEAST_3",
+	}
+	meta := &MongoDBClient{ClusterCatalog: defaultClusterCatalog()}
+
+	if _, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta); err != nil {
+		t.Fatalf("expected an unchanged cluster outside of the catalog to plan, got %s", err)
+	}
+
+	if _, err := testResourceDiff(t, resourceCluster(), "", nil, raw, meta); err == nil {
+		t.Fatal("expected a new cluster in a region outside of the catalog to fail")
+	}
+
+	raw["size"] = "M15"
+	if _, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta); err == nil {
+		t.Fatal("expected a change to a size outside of the catalog to fail")
+	}
+}
+
 func TestMongodbatlasCluster_importBasic(t *testing.T) {
 	projectName := "test"
 	projectID := "5ba8c5c396e8211ae8272486"
@@ -117,7 +689,7 @@ func testAccCheckMongodbatlasClusterExists(n string, res *ma.Cluster) resource.T
 			return errors.New("No Cluster name is set")
 		}
 
-		client := testAccProvider.Meta().(*ma.Client)
+		client := testAccProvider.Meta().(*MongoDBClient).Client
 
 		c, _, err := client.Clusters.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["name"])
 		if err != nil {
@@ -130,7 +702,7 @@ func testAccCheckMongodbatlasClusterExists(n string, res *ma.Cluster) resource.T
 }
 
 func testAccCheckMongodbatlasClusterDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ma.Client)
+	client := testAccProvider.Meta().(*MongoDBClient).Client
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "mongodbatlas_cluster" {
 			continue
@@ -170,3 +742,256 @@ data "mongodbatlas_project" "test" {
   name = "%s"
 }`, clusterName, size, diskSize, projectName)
 }
+
+func testAccMongodbatlasClusterAdvancedConfiguration(projectName, clusterName, javascriptEnabled, tlsProtocol string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+
+  advanced_configuration {
+    javascript_enabled = %s
+    minimum_enabled_tls_protocol = "%s"
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, javascriptEnabled, tlsProtocol, projectName)
+}
+
+func testAccMongodbatlasClusterAutoScalingCompute(projectName, clusterName, maxInstanceSize string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = true
+  auto_scaling_compute_enabled = true
+  auto_scaling_compute_scale_down_enabled = true
+  provider_auto_scaling_compute_min_instance_size = "M10"
+  provider_auto_scaling_compute_max_instance_size = "%s"
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, maxInstanceSize, projectName)
+}
+
+func testAccMongodbatlasClusterBiConnector(projectName, clusterName, enabled, readPreference string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+
+  bi_connector {
+    enabled = %s
+    read_preference = "%s"
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, enabled, readPreference, projectName)
+}
+
+func testAccMongodbatlasClusterGlobalCluster(projectName, clusterName string, numShards int) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = ""
+  size = "M30"
+  backup = false
+  disk_gb_enabled = false
+  cluster_type = "GEOSHARDED"
+
+  replication_specs {
+    zone_name = "Zone 1"
+    num_shards = %d
+
+    regions_config {
+      region = "US_EAST_1"
+      priority = 7
+      electable_nodes = 3
+    }
+  }
+
+  replication_specs {
+    zone_name = "Zone 2"
+    num_shards = 1
+
+    regions_config {
+      region = "EU_WEST_1"
+      priority = 7
+      electable_nodes = 3
+    }
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, numShards, projectName)
+}
+
+func testAccMongodbatlasClusterLabels(projectName, clusterName, owner string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+
+  labels {
+    key = "owner"
+    value = "%s"
+  }
+
+  labels {
+    key = "cost-center"
+    value = "1234"
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, owner, projectName)
+}
+
+func testAccMongodbatlasClusterProvisionedIOPS(projectName, clusterName, diskSize string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M30"
+  disk_size_gb = "%s"
+  backup = false
+  disk_gb_enabled = false
+  provider_volume_type = "PROVISIONED"
+  provider_disk_iops = 1000
+  provider_encrypt_ebs_volume = true
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, diskSize, projectName)
+}
+
+func testAccMongodbatlasClusterTenant(projectName, clusterName string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "3.6"
+  provider_name = "TENANT"
+  backing_provider = "AWS"
+  region = "US_EAST_1"
+  size = "M2"
+  backup = false
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, projectName)
+}
+
+func testAccMongodbatlasClusterTerminationProtection(projectName, clusterName, terminationProtection, allowReplacement string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+  termination_protection_enabled = %s
+  allow_replacement = %s
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, terminationProtection, allowReplacement, projectName)
+}
+
+func TestMongodbatlasCluster_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceCluster(), map[string]interface{}{
+		"group": "5ba8c5c396e8211ae8272486",
+		"name":  "test",
+	}, "5ba8c5c396e8211ae8272487")
+}
+
+func TestMongodbatlasCluster_zonedToReplicaSet(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":                  "test",
+		"group":                 "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version": "4.4",
+		"backup":                false,
+		"size":                  "M30",
+		"provider_name":         "AWS",
+		"region":                "",
+		"replication_factor":    0,
+		"cluster_type":          "REPLICASET",
+		"replication_spec": []interface{}{
+			map[string]interface{}{"region": "US_EAST_1", "priority": 7, "electable_nodes": 3},
+		},
+	}
+	attributes := map[string]string{
+		"id":                             "5ba8c5c396e8211ae8272487",
+		"name":                           "test",
+		"group":                          "5ba8c5c396e8211ae8272486",
+		"mongodb_major_version":          "4.4",
+		"backup":                         "false",
+		"size":                           "M30",
+		"provider_name":                  "AWS",
+		"region":                         "",
+		"replication_factor":             "0",
+		"cluster_type":                   "GEOSHARDED",
+		"num_shards":                     "1",
+		"replication_specs.#":            "2",
+		"replication_specs.0.zone_name":  "Zone 1",
+		"replication_specs.0.num_shards": "1",
+		"replication_specs.1.zone_name":  "Zone 2",
+		"replication_specs.1.num_shards": "1",
+	}
+	meta := &MongoDBClient{ClusterCatalog: defaultClusterCatalog()}
+
+	_, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta)
+	if err == nil || !strings.Contains(err.Error(), "REPLICASET") {
+		t.Fatalf("expected turning a Global Cluster into a replica set to fail, got %v", err)
+	}
+
+	raw["cluster_type"] = "GEOSHARDED"
+	_, err = testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta)
+	if err == nil || !strings.Contains(err.Error(), "2 zones") {
+		t.Fatalf("expected describing a cluster with 2 zones with replication_spec to fail, got %v", err)
+	}
+
+	// A cluster with a single zone can move between both formats
+	attributes["cluster_type"] = "REPLICASET"
+	attributes["replication_specs.#"] = "1"
+	delete(attributes, "replication_specs.1.zone_name")
+	delete(attributes, "replication_specs.1.num_shards")
+	raw["cluster_type"] = "REPLICASET"
+	if _, err := testResourceDiff(t, resourceCluster(), "5ba8c5c396e8211ae8272487", attributes, raw, meta); err != nil {
+		t.Fatalf("expected a cluster with a single zone to use replication_spec, got %s", err)
+	}
+}
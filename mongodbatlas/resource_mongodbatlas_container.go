@@ -55,6 +55,14 @@ func resourceContainer() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"azure_subscription_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vnet_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"identifier": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -75,7 +83,7 @@ func resourceContainer() *schema.Resource {
 }
 
 func resourceContainerCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	params := ma.Container{
 		AtlasCidrBlock: d.Get("atlas_cidr_block").(string),
@@ -91,6 +99,10 @@ func resourceContainerCreate(d *schema.ResourceData, meta interface{}) error {
 		params.NetworkName = d.Get("network_name").(string)
 	}
 
+	if params.ProviderName == "AZURE" {
+		params.Region = d.Get("region").(string)
+	}
+
 	container, _, err := client.Containers.Create(d.Get("group").(string), &params)
 
 	if err != nil {
@@ -112,10 +124,15 @@ func resourceContainerCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceContainerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
-	c, _, err := client.Containers.Get(d.Get("group").(string), d.Id())
+	c, resp, err := client.Containers.Get(d.Get("group").(string), d.Id())
 	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Container %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading MongoDB Container %s: %s", d.Id(), err)
 	}
 
@@ -148,12 +165,23 @@ func resourceContainerRead(d *schema.ResourceData, meta interface{}) error {
 			log.Printf("[WARN] Error setting network_name for (%s): %s", d.Id(), err)
 		}
 	}
+	if d.Get("provider_name").(string) == "AZURE" {
+		if err := d.Set("region", c.Region); err != nil {
+			log.Printf("[WARN] Error setting region for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("azure_subscription_id", c.AzureSubscriptionID); err != nil {
+			log.Printf("[WARN] Error setting azure_subscription_id for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("vnet_name", c.VnetName); err != nil {
+			log.Printf("[WARN] Error setting vnet_name for (%s): %s", d.Id(), err)
+		}
+	}
 
 	return nil
 }
 
 func resourceContainerUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	requestUpdate := false
 
 	c, _, err := client.Containers.Get(d.Get("group").(string), d.Id())
@@ -170,7 +198,11 @@ func resourceContainerUpdate(d *schema.ResourceData, meta interface{}) error {
 		requestUpdate = true
 	}
 	if d.HasChange("region") {
-		c.RegionName = d.Get("region").(string)
+		if c.ProviderName == "AZURE" {
+			c.Region = d.Get("region").(string)
+		} else {
+			c.RegionName = d.Get("region").(string)
+		}
 		requestUpdate = true
 	}
 	if d.HasChange("gcp_project_id") {
@@ -207,7 +239,7 @@ func resourceContainerUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceContainerDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	group := d.Get("group").(string)
 
 	_, err := client.Containers.Delete(group, d.Id())
@@ -232,7 +264,7 @@ func resourceContainerImportState(d *schema.ResourceData, meta interface{}) ([]*
 	}
 	gid := parts[0]
 	containerID := parts[1]
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	c, _, err := client.Containers.Get(gid, containerID)
 	if err != nil {
 		return nil, fmt.Errorf("Error reading MongoDB Container %s: %s", containerID, err)
@@ -268,6 +300,17 @@ func resourceContainerImportState(d *schema.ResourceData, meta interface{}) ([]*
 			log.Printf("[WARN] Error setting network_name for (%s): %s", d.Id(), err)
 		}
 	}
+	if d.Get("provider_name").(string) == "AZURE" {
+		if err := d.Set("region", c.Region); err != nil {
+			log.Printf("[WARN] Error setting region for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("azure_subscription_id", c.AzureSubscriptionID); err != nil {
+			log.Printf("[WARN] Error setting azure_subscription_id for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("vnet_name", c.VnetName); err != nil {
+			log.Printf("[WARN] Error setting vnet_name for (%s): %s", d.Id(), err)
+		}
+	}
 
 	return []*schema.ResourceData{d}, nil
 
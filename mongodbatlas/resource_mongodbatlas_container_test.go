@@ -45,6 +45,34 @@ func TestAccMongodbatlasContainer_basic(t *testing.T) {
 	})
 }
 
+func TestAccMongodbatlasContainer_azure(t *testing.T) {
+	var container ma.Container
+	projectName := "test"
+	cidrBlock := "192.168.208.0/21"
+
+	resourceName := "mongodbatlas_container.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasContainerAzure(projectName, cidrBlock),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasContainerExists(resourceName, &container),
+					resource.TestCheckResourceAttrSet(resourceName, "group"),
+					resource.TestCheckResourceAttrSet(resourceName, "identifier"),
+					resource.TestCheckResourceAttrSet(resourceName, "azure_subscription_id"),
+					resource.TestCheckResourceAttr(resourceName, "atlas_cidr_block", cidrBlock),
+					resource.TestCheckResourceAttr(resourceName, "provider_name", "AZURE"),
+					resource.TestCheckResourceAttr(resourceName, "region", "US_EAST_2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckMongodbatlasContainerExists(n string, res *ma.Container) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -60,7 +88,7 @@ func testAccCheckMongodbatlasContainerExists(n string, res *ma.Container) resour
 			return errors.New("No Container group ID is set")
 		}
 
-		client := testAccProvider.Meta().(*ma.Client)
+		client := testAccProvider.Meta().(*MongoDBClient).Client
 
 		c, _, err := client.Containers.Get(rs.Primary.Attributes["group"], rs.Primary.ID)
 		if err != nil {
@@ -73,7 +101,7 @@ func testAccCheckMongodbatlasContainerExists(n string, res *ma.Container) resour
 }
 
 func testAccCheckMongodbatlasContainerDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ma.Client)
+	client := testAccProvider.Meta().(*MongoDBClient).Client
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "mongodbatlas_container" {
 			continue
@@ -108,3 +136,24 @@ data "mongodbatlas_project" "test" {
   name = "%s"
 }`, cidrBlock, projectName)
 }
+
+func testAccMongodbatlasContainerAzure(projectName, cidrBlock string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_container" "test" {
+  group = "${data.mongodbatlas_project.test.id}"
+  atlas_cidr_block = "%s"
+  provider_name = "AZURE"
+  region = "US_EAST_2"
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, cidrBlock, projectName)
+}
+
+func TestMongodbatlasContainer_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceContainer(), map[string]interface{}{
+		"group":            "5ba8c5c396e8211ae8272486",
+		"atlas_cidr_block": "10.8.0.0/21",
+		"provider_name":    "AWS",
+	}, "5ba8c5c396e8211ae8272487")
+}
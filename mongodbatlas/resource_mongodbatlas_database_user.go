@@ -1,13 +1,18 @@
 package mongodbatlas
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"log"
+	"math/big"
+	"regexp"
 	"strings"
+	"time"
 
 	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourceDatabaseUser() *schema.Resource {
@@ -19,6 +24,21 @@ func resourceDatabaseUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDatabaseUserImportState,
 		},
+		CustomizeDiff: resourceDatabaseUserCustomizeDiff,
+
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDatabaseUserResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDatabaseUserStateUpgradeV0,
+				Version: 0,
+			},
+			{
+				Type:    resourceDatabaseUserResourceV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDatabaseUserStateUpgradeV1,
+				Version: 1,
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			"group": {
@@ -32,16 +52,54 @@ func resourceDatabaseUser() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"generate_password"},
+			},
+			"aws_iam_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "NONE",
+				ValidateFunc: validation.StringInSlice([]string{"NONE", "USER", "ROLE"}, false),
+			},
+			"generate_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"password_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      32,
+				ValidateFunc: validation.IntBetween(8, 256),
+			},
+			"password_charset": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "alphanumeric",
+				ValidateFunc: validation.StringInSlice([]string{"alphanumeric", "alphanumeric_special"}, false),
+			},
+			"rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"generated_password": {
 				Type:      schema.TypeString,
-				Optional:  true,
+				Computed:  true,
 				Sensitive: true,
 			},
+			"password_rotated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"database": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"roles": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -60,20 +118,74 @@ func resourceDatabaseUser() *schema.Resource {
 					},
 				},
 			},
+			"delete_after_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateDeleteAfterDate,
+				DiffSuppressFunc: suppressEquivalentRFC3339,
+			},
+			"labels": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "CLUSTER",
+							ValidateFunc: validation.StringInSlice([]string{"CLUSTER", "DATA_LAKE"}, false),
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func resourceDatabaseUserCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	params := ma.DatabaseUser{
 		Username:     d.Get("username").(string),
 		Password:     d.Get("password").(string),
 		DatabaseName: d.Get("database").(string),
+		Labels:       readLabelsFromSchema(d.Get("labels").(*schema.Set).List(), nil),
+		AWSIAMType:   d.Get("aws_iam_type").(string),
 	}
 
-	params.Roles = readRolesFromSchema(d.Get("roles").([]interface{}))
+	params.Roles = readRolesFromSchema(d.Get("roles").(*schema.Set).List())
+	params.Scopes = readScopesFromSchema(d.Get("scopes").(*schema.Set).List())
+	if deleteAfterDate := d.Get("delete_after_date").(string); deleteAfterDate != "" {
+		params.DeleteAfterDate = &deleteAfterDate
+	}
+
+	if d.Get("generate_password").(bool) {
+		password, err := generatePassword(d.Get("password_length").(int), d.Get("password_charset").(string))
+		if err != nil {
+			return fmt.Errorf("Error generating password for MongoDB DatabaseUser: %s", err)
+		}
+		params.Password = password
+	}
 
 	databaseUser, _, err := client.DatabaseUsers.Create(d.Get("group").(string), &params)
 	if err != nil {
@@ -82,14 +194,24 @@ func resourceDatabaseUserCreate(d *schema.ResourceData, meta interface{}) error
 	d.SetId(databaseUser.Username)
 	log.Printf("[INFO] MongoDB DatabaseUser ID: %s", d.Id())
 
+	if d.Get("generate_password").(bool) {
+		setGeneratedPassword(d, params.Password, time.Now().UTC().Format(time.RFC3339))
+	}
+
 	return resourceDatabaseUserRead(d, meta)
 }
 
 func resourceDatabaseUserRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
-	u, _, err := client.DatabaseUsers.Get(d.Get("group").(string), d.Id())
+	u, resp, err := client.DatabaseUsers.Get(d.Get("group").(string), d.Get("database").(string), d.Id())
 	if err != nil {
+		// Atlas deletes users once their delete_after_date has passed
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB DatabaseUser %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading MongoDB DatabaseUser %s (%s): %s", d.Id(), d.Get("group").(string), err)
 	}
 
@@ -99,6 +221,23 @@ func resourceDatabaseUserRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("database", u.DatabaseName); err != nil {
 		log.Printf("[WARN] Error setting database for (%s): %s", d.Id(), err)
 	}
+	awsIAMType := u.AWSIAMType
+	if awsIAMType == "" {
+		awsIAMType = "NONE"
+	}
+	if err := d.Set("aws_iam_type", awsIAMType); err != nil {
+		log.Printf("[WARN] Error setting aws_iam_type for (%s): %s", d.Id(), err)
+	}
+	deleteAfterDate := ""
+	if u.DeleteAfterDate != nil {
+		deleteAfterDate = *u.DeleteAfterDate
+	}
+	if err := d.Set("delete_after_date", deleteAfterDate); err != nil {
+		log.Printf("[WARN] Error setting delete_after_date for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("labels", flattenLabels(u.Labels, d.Get("labels").(*schema.Set).List(), nil)); err != nil {
+		log.Printf("[WARN] Error setting labels for (%s): %s", d.Id(), err)
+	}
 	rolesMap := make([]map[string]interface{}, len(u.Roles))
 	for i, r := range u.Roles {
 		rolesMap[i] = map[string]interface{}{
@@ -110,43 +249,113 @@ func resourceDatabaseUserRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("roles", rolesMap); err != nil {
 		log.Printf("[WARN] Error setting roles for (%s): %s", d.Id(), err)
 	}
+	scopesMap := make([]map[string]interface{}, len(u.Scopes))
+	for i, s := range u.Scopes {
+		scopesMap[i] = map[string]interface{}{
+			"name": s.Name,
+			"type": s.Type,
+		}
+	}
+	if err := d.Set("scopes", scopesMap); err != nil {
+		log.Printf("[WARN] Error setting scopes for (%s): %s", d.Id(), err)
+	}
 
 	return nil
 }
 
 func resourceDatabaseUserUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	requestUpdate := false
 
-	u, _, err := client.DatabaseUsers.Get(d.Get("group").(string), d.Id())
+	u, _, err := client.DatabaseUsers.Get(d.Get("group").(string), d.Get("database").(string), d.Id())
 	if err != nil {
 		return fmt.Errorf("Error reading MongoDB DatabaseUser %s: %s", d.Id(), err)
 	}
+	// Atlas leaves out empty scopes and labels, which mustn't be sent back as null
+	if u.Scopes == nil {
+		u.Scopes = []ma.Scope{}
+	}
+	if u.Labels == nil {
+		u.Labels = []ma.Label{}
+	}
 
 	if d.HasChange("password") {
 		u.Password = d.Get("password").(string)
 		requestUpdate = true
 	}
+	rotatePassword := databaseUserPasswordRotates(d)
+	if rotatePassword {
+		u.Password, err = generatePassword(d.Get("password_length").(int), d.Get("password_charset").(string))
+		if err != nil {
+			return fmt.Errorf("Error generating password for MongoDB DatabaseUser %s: %s", d.Id(), err)
+		}
+		requestUpdate = true
+	}
+	if !d.Get("generate_password").(bool) {
+		setGeneratedPassword(d, "", "")
+	}
 	if d.HasChange("roles") {
-		u.Roles = readRolesFromSchema(d.Get("roles").([]interface{}))
+		u.Roles = readRolesFromSchema(d.Get("roles").(*schema.Set).List())
+		requestUpdate = true
+	}
+	if d.HasChange("scopes") {
+		u.Scopes = readScopesFromSchema(d.Get("scopes").(*schema.Set).List())
+		requestUpdate = true
+	}
+	if d.HasChange("delete_after_date") {
+		// An empty date makes the user permanent
+		deleteAfterDate := d.Get("delete_after_date").(string)
+		u.DeleteAfterDate = &deleteAfterDate
+		requestUpdate = true
+	}
+	if d.HasChange("labels") {
+		u.Labels = readLabelsFromSchema(d.Get("labels").(*schema.Set).List(), nil)
 		requestUpdate = true
 	}
 
 	if requestUpdate {
-		_, _, err := client.DatabaseUsers.Update(d.Get("group").(string), d.Id(), u)
+		_, _, err := client.DatabaseUsers.Update(d.Get("group").(string), d.Get("database").(string), d.Id(), u)
 		if err != nil {
 			return fmt.Errorf("Error updating MongoDB DatabaseUser %s: %s", d.Id(), err)
 		}
+		if rotatePassword {
+			setGeneratedPassword(d, u.Password, time.Now().UTC().Format(time.RFC3339))
+		}
 		return resourceDatabaseUserRead(d, meta)
 	}
 	return nil
 }
 
+func setGeneratedPassword(d *schema.ResourceData, password, rotatedAt string) {
+	if err := d.Set("generated_password", password); err != nil {
+		log.Printf("[WARN] Error setting generated_password for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("password_rotated_at", rotatedAt); err != nil {
+		log.Printf("[WARN] Error setting password_rotated_at for (%s): %s", d.Id(), err)
+	}
+}
+
+// schemaDiff is implemented by both *schema.ResourceData and *schema.ResourceDiff.
+type schemaDiff interface {
+	Get(string) interface{}
+	HasChange(string) bool
+}
+
+// databaseUserPasswordRotates tells whether a new password must be generated, i.e. when
+// password generation is turned on, or its options or rotation_trigger change.
+func databaseUserPasswordRotates(d schemaDiff) bool {
+	if !d.Get("generate_password").(bool) {
+		return false
+	}
+	return d.HasChange("generate_password") || d.HasChange("rotation_trigger") ||
+		d.HasChange("password_length") || d.HasChange("password_charset")
+}
+
 func resourceDatabaseUserDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	log.Printf("[DEBUG] MongoDB DatabaseUser destroy: %v", d.Id())
-	_, err := client.DatabaseUsers.Delete(d.Get("group").(string), d.Id())
+	_, err := client.DatabaseUsers.Delete(d.Get("group").(string), d.Get("database").(string), d.Id())
 	if err != nil {
 		return fmt.Errorf("Error destroying MongoDB DatabaseUser %s: %s", d.Id(), err)
 	}
@@ -154,17 +363,176 @@ func resourceDatabaseUserDelete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+// resourceDatabaseUserCustomizeDiff validates AWS IAM users, shows the generated password
+// as changing when it rotates, and checks that the clusters the user is scoped to exist in
+// the group, so a typo doesn't fail the apply or lock the user out of every cluster.
+func resourceDatabaseUserCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateDatabaseUserAWSIAM(d); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && databaseUserPasswordRotates(d) {
+		if err := d.SetNewComputed("generated_password"); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("password_rotated_at"); err != nil {
+			return err
+		}
+	}
+	if !d.Get("generate_password").(bool) && d.Get("generated_password").(string) != "" {
+		if err := d.SetNew("generated_password", ""); err != nil {
+			return err
+		}
+		if err := d.SetNew("password_rotated_at", ""); err != nil {
+			return err
+		}
+	}
+
+	// Names of clusters created in the same apply may not be known yet
+	if !d.HasChange("scopes") || !d.NewValueKnown("scopes") || !d.NewValueKnown("group") {
+		return nil
+	}
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+
+	for _, s := range readScopesFromSchema(d.Get("scopes").(*schema.Set).List()) {
+		if s.Type != "CLUSTER" {
+			continue
+		}
+		_, resp, err := client.Clusters.Get(group, s.Name)
+		if err != nil {
+			if isNotFound(resp) {
+				return fmt.Errorf("scopes: cluster %s doesn't exist in group %s", s.Name, group)
+			}
+			return fmt.Errorf("Error reading MongoDB Cluster %s of scopes: %s", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateDeleteAfterDate warns about dates in the past, since Atlas would delete the
+// user right after creating it.
+func validateDeleteAfterDate(v interface{}, k string) (ws []string, errors []error) {
+	date, err := time.Parse(time.RFC3339, v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a RFC3339 date, e.g. 2019-10-21T18:00:00Z: %s", k, err))
+		return
+	}
+	if date.Before(time.Now()) {
+		ws = append(ws, fmt.Sprintf("%q %s is in the past, Atlas will delete it as soon as it is created or updated", k, v.(string)))
+	}
+	return
+}
+
+// suppressEquivalentRFC3339 ignores differences between the configured date and the
+// one returned by Atlas, which is in UTC.
+func suppressEquivalentRFC3339(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+const (
+	passwordAlphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	passwordSpecial      = "!#$%&()*+,-.:;<=>?[]^_{|}~"
+)
+
+// generatePassword returns a password of the given length, drawn uniformly from the charset
+// with crypto/rand.
+func generatePassword(length int, charset string) (string, error) {
+	chars := passwordAlphanumeric
+	if charset == "alphanumeric_special" {
+		chars += passwordSpecial
+	}
+
+	password := make([]byte, length)
+	max := big.NewInt(int64(len(chars)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		password[i] = chars[n.Int64()]
+	}
+	return string(password), nil
+}
+
+var (
+	awsIAMARNRegexp = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::[0-9]{12}:(user|role)/.+$`)
+	groupIDRegexp   = regexp.MustCompile(`^[0-9a-f]{24}$`)
+)
+
+// validateDatabaseUserAWSIAM checks that AWS IAM users are named after the ARN of an IAM
+// user or role, authenticate with the $external database and have no password.
+func validateDatabaseUserAWSIAM(d *schema.ResourceDiff) error {
+	awsIAMType := d.Get("aws_iam_type").(string)
+	if awsIAMType == "NONE" {
+		return nil
+	}
+
+	if d.Get("database").(string) != "$external" {
+		return fmt.Errorf("database must be $external when aws_iam_type is %s", awsIAMType)
+	}
+	if d.Get("password").(string) != "" || d.Get("generate_password").(bool) {
+		return fmt.Errorf("password and generate_password can't be set when aws_iam_type is %s", awsIAMType)
+	}
+
+	// The ARN of a role created in the same apply isn't known yet
+	if !d.NewValueKnown("username") {
+		return nil
+	}
+	username := d.Get("username").(string)
+	match := awsIAMARNRegexp.FindStringSubmatch(username)
+	if match == nil {
+		return fmt.Errorf("username must be the ARN of an IAM user or role when aws_iam_type is %s, got %s", awsIAMType, username)
+	}
+	if strings.ToUpper(match[2]) != awsIAMType {
+		return fmt.Errorf("username must be the ARN of an IAM %s when aws_iam_type is %s, got %s", strings.ToLower(awsIAMType), awsIAMType, username)
+	}
+	return nil
+}
+
+// parseDatabaseUserImportID splits an import ID of the form {group id}-{username} on the first
+// dash only, since usernames, e.g. ARNs, can contain dashes, colons and slashes. ARNs are
+// AWS IAM users, authenticated by the $external database instead of admin.
+func parseDatabaseUserImportID(id string) (gid, database, username string, err error) {
+	// Usernames can contain slashes, like the ARNs of AWS IAM users
+	if parts := strings.SplitN(id, "/", 3); len(parts) == 3 && groupIDRegexp.MatchString(parts[0]) {
+		if parts[1] == "" || parts[2] == "" {
+			return "", "", "", errors.New("To import a user, use the format {group id}/{database}/{username}")
+		}
+		return parts[0], parts[1], parts[2], nil
+	}
+
+	// The older {group id}-{username} format guesses the database from the username
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 || !groupIDRegexp.MatchString(parts[0]) || parts[1] == "" {
+		return "", "", "", errors.New("To import a user, use the format {group id}/{database}/{username}")
+	}
+	gid, username = parts[0], parts[1]
+
+	database = "admin"
+	if awsIAMARNRegexp.MatchString(username) {
+		database = "$external"
+	}
+	return gid, database, username, nil
+}
+
 func resourceDatabaseUserImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
-	parts := strings.SplitN(d.Id(), "-", 2)
-	if len(parts) != 2 {
-		return nil, errors.New("To import a user, use the format {group id}-{username}")
+	gid, database, username, err := parseDatabaseUserImportID(d.Id())
+	if err != nil {
+		return nil, err
 	}
-	gid := parts[0]
-	username := parts[1]
 
-	u, _, err := client.DatabaseUsers.Get(gid, username)
+	u, _, err := client.DatabaseUsers.Get(gid, database, username)
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't import user %s in group %s, error: %s", username, gid, err.Error())
 	}
@@ -173,6 +541,9 @@ func resourceDatabaseUserImportState(d *schema.ResourceData, meta interface{}) (
 	if err := d.Set("group", u.GroupID); err != nil {
 		log.Printf("[WARN] Error setting group for (%s): %s", d.Id(), err)
 	}
+	if err := d.Set("database", u.DatabaseName); err != nil {
+		log.Printf("[WARN] Error setting database for (%s): %s", d.Id(), err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -190,3 +561,16 @@ func readRolesFromSchema(rolesMap []interface{}) (roles []ma.Role) {
 	}
 	return roles
 }
+
+func readScopesFromSchema(scopesMap []interface{}) (scopes []ma.Scope) {
+	scopes = make([]ma.Scope, len(scopesMap))
+	for i, s := range scopesMap {
+		scopeMap := s.(map[string]interface{})
+
+		scopes[i] = ma.Scope{
+			Name: scopeMap["name"].(string),
+			Type: scopeMap["type"].(string),
+		}
+	}
+	return scopes
+}
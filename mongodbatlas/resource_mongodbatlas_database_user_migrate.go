@@ -0,0 +1,171 @@
+package mongodbatlas
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDatabaseUserResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"aws_iam_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "NONE",
+			},
+			"generate_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"password_length": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  32,
+			},
+			"password_charset": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "alphanumeric",
+			},
+			"rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"generated_password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"password_rotated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"database": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"collection": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"delete_after_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"labels": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "CLUSTER",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceDatabaseUserStateUpgradeV0 turns roles into a set. Roles without a collection get
+// an empty one, so they hash like the roles of the configuration and the plan stays empty.
+func resourceDatabaseUserStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	roles, ok := rawState["roles"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+
+	for _, r := range roles {
+		role, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := role["collection"]; !ok || v == nil {
+			role["collection"] = ""
+		}
+	}
+
+	return rawState, nil
+}
+
+// resourceDatabaseUserResourceV1 is the schema with roles as a set and scopes as a list.
+func resourceDatabaseUserResourceV1() *schema.Resource {
+	r := resourceDatabaseUserResourceV0()
+	r.Schema["roles"].Type = schema.TypeSet
+	return r
+}
+
+// resourceDatabaseUserStateUpgradeV1 turns scopes into a set. Scopes without a type get
+// the CLUSTER default, so they hash like the scopes of the configuration.
+func resourceDatabaseUserStateUpgradeV1(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	scopes, ok := rawState["scopes"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+
+	for _, s := range scopes {
+		scope, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := scope["type"]; !ok || v == nil || v == "" {
+			scope["type"] = "CLUSTER"
+		}
+	}
+
+	return rawState, nil
+}
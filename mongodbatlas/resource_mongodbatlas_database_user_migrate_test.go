@@ -0,0 +1,124 @@
+package mongodbatlas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testResourceDatabaseUserResourceV0() map[string]interface{} {
+	return map[string]interface{}{
+		"database": "admin",
+		"group":    "812nf72jf82j72j72hejw8yr",
+		"id":       "test",
+		"username": "test",
+		"roles": []interface{}{
+			map[string]interface{}{
+				"name":     "readWrite",
+				"database": "app",
+			},
+			map[string]interface{}{
+				"name":       "read",
+				"database":   "reporting",
+				"collection": "orders",
+			},
+			map[string]interface{}{
+				"name":       "read",
+				"database":   "admin",
+				"collection": nil,
+			},
+		},
+	}
+}
+
+func testResourceDatabaseUserResourceV1() map[string]interface{} {
+	v0 := testResourceDatabaseUserResourceV0()
+	return map[string]interface{}{
+		"database": v0["database"],
+		"group":    v0["group"],
+		"id":       v0["id"],
+		"username": v0["username"],
+		"roles": []interface{}{
+			map[string]interface{}{
+				"name":       "readWrite",
+				"database":   "app",
+				"collection": "",
+			},
+			map[string]interface{}{
+				"name":       "read",
+				"database":   "reporting",
+				"collection": "orders",
+			},
+			map[string]interface{}{
+				"name":       "read",
+				"database":   "admin",
+				"collection": "",
+			},
+		},
+	}
+}
+
+func TestResourceDatabaseUserStateUpgradeV0_roles(t *testing.T) {
+	expected := testResourceDatabaseUserResourceV1()
+	actual, err := resourceDatabaseUserStateUpgradeV0(testResourceDatabaseUserResourceV0(), nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
+func TestResourceDatabaseUserStateUpgradeV0_noRoles(t *testing.T) {
+	expected := map[string]interface{}{
+		"database": "admin",
+		"id":       "test",
+	}
+	actual, err := resourceDatabaseUserStateUpgradeV0(map[string]interface{}{
+		"database": "admin",
+		"id":       "test",
+	}, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
+func TestResourceDatabaseUserStateUpgradeV1_scopes(t *testing.T) {
+	expected := map[string]interface{}{
+		"id": "test",
+		"scopes": []interface{}{
+			map[string]interface{}{
+				"name": "cluster0",
+				"type": "CLUSTER",
+			},
+			map[string]interface{}{
+				"name": "lake0",
+				"type": "DATA_LAKE",
+			},
+		},
+	}
+	actual, err := resourceDatabaseUserStateUpgradeV1(map[string]interface{}{
+		"id": "test",
+		"scopes": []interface{}{
+			map[string]interface{}{
+				"name": "cluster0",
+				"type": nil,
+			},
+			map[string]interface{}{
+				"name": "lake0",
+				"type": "DATA_LAKE",
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
@@ -3,11 +3,17 @@ package mongodbatlas
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -49,6 +55,265 @@ func TestAccMongodbatlasDatabaseUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccMongodbatlasDatabaseUser_scopes(t *testing.T) {
+	var databaseUser ma.DatabaseUser
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	databaseUserName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	databaseUserPassword := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resourceName := "mongodbatlas_database_user.test"
+	scope := schema.HashResource(resourceDatabaseUser().Schema["scopes"].Elem.(*schema.Resource))(map[string]interface{}{"name": clusterName, "type": "CLUSTER"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasDatabaseUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasDatabaseUserScopesCluster(projectName, clusterName),
+			},
+			{
+				Config:      testAccMongodbatlasDatabaseUserScopes(projectName, clusterName, databaseUserName, databaseUserPassword, "does-not-exist"),
+				ExpectError: regexp.MustCompile("cluster does-not-exist doesn't exist"),
+			},
+			{
+				Config: testAccMongodbatlasDatabaseUserScopes(projectName, clusterName, databaseUserName, databaseUserPassword, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasDatabaseUserExists(resourceName, &databaseUser),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("scopes.%d.name", scope), clusterName),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("scopes.%d.type", scope), "CLUSTER"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMongodbatlasDatabaseUser_deleteAfterDate(t *testing.T) {
+	var databaseUser ma.DatabaseUser
+	projectName := "test"
+	databaseUserName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	databaseUserPassword := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	deleteAfterDate := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resourceName := "mongodbatlas_database_user.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasDatabaseUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasDatabaseUserDeleteAfterDate(projectName, databaseUserName, databaseUserPassword, deleteAfterDate, "alice"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasDatabaseUserExists(resourceName, &databaseUser),
+					resource.TestCheckResourceAttr(resourceName, "delete_after_date", deleteAfterDate),
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "1"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasDatabaseUserDeleteAfterDate(projectName, databaseUserName, databaseUserPassword, deleteAfterDate, "bob"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasDatabaseUserExists(resourceName, &databaseUser),
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestMongodbatlasDatabaseUser_validateDeleteAfterDate(t *testing.T) {
+	cases := []struct {
+		date     string
+		warnings int
+		errors   int
+	}{
+		{date: time.Now().Add(time.Hour).Format(time.RFC3339)},
+		{date: "2019-01-01T00:00:00Z", warnings: 1},
+		{date: "2019-01-01", errors: 1},
+	}
+
+	for _, c := range cases {
+		ws, errs := validateDeleteAfterDate(c.date, "delete_after_date")
+		if len(ws) != c.warnings || len(errs) != c.errors {
+			t.Errorf("%s: expected %d warnings and %d errors, got %v and %v", c.date, c.warnings, c.errors, ws, errs)
+		}
+	}
+
+	if !suppressEquivalentRFC3339("delete_after_date", "2019-10-21T16:00:00Z", "2019-10-21T18:00:00+02:00", nil) {
+		t.Error("expected dates in different time zones to be equivalent")
+	}
+}
+
+func TestAccMongodbatlasDatabaseUser_generatePassword(t *testing.T) {
+	var databaseUser ma.DatabaseUser
+	projectName := "test"
+	databaseUserName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	var generatedPassword string
+
+	resourceName := "mongodbatlas_database_user.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasDatabaseUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasDatabaseUserGeneratePassword(projectName, databaseUserName, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasDatabaseUserExists(resourceName, &databaseUser),
+					resource.TestCheckResourceAttrSet(resourceName, "password_rotated_at"),
+					testAccCheckMongodbatlasDatabaseUserGeneratedPassword(resourceName, &generatedPassword, false),
+				),
+			},
+			{
+				Config: testAccMongodbatlasDatabaseUserGeneratePassword(projectName, databaseUserName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasDatabaseUserExists(resourceName, &databaseUser),
+					testAccCheckMongodbatlasDatabaseUserGeneratedPassword(resourceName, &generatedPassword, true),
+				),
+			},
+		},
+	})
+}
+
+func TestMongodbatlasDatabaseUser_generatePassword(t *testing.T) {
+	password, err := generatePassword(40, "alphanumeric")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != 40 {
+		t.Errorf("expected a password of 40 characters, got %d", len(password))
+	}
+	if strings.Trim(password, passwordAlphanumeric) != "" {
+		t.Errorf("expected an alphanumeric password, got %s", password)
+	}
+
+	password, err = generatePassword(200, "alphanumeric_special")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Trim(password, passwordAlphanumeric+passwordSpecial) != "" {
+		t.Errorf("expected a password of alphanumeric and special characters, got %s", password)
+	}
+
+	other, _ := generatePassword(200, "alphanumeric_special")
+	if other == password {
+		t.Error("expected generated passwords to differ")
+	}
+}
+
+// testAccCheckMongodbatlasDatabaseUserGeneratedPassword records the generated password
+// and, when rotated is set, checks that it differs from the previously recorded one.
+func testAccCheckMongodbatlasDatabaseUserGeneratedPassword(n string, previous *string, rotated bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		password := rs.Primary.Attributes["generated_password"]
+		if len(password) != 24 {
+			return fmt.Errorf("expected a generated password of 24 characters, got %d", len(password))
+		}
+		if rotated && password == *previous {
+			return errors.New("expected the generated password to be rotated")
+		}
+
+		*previous = password
+		return nil
+	}
+}
+
+func TestAccMongodbatlasDatabaseUser_awsIAM(t *testing.T) {
+	var databaseUser ma.DatabaseUser
+	projectName := "test"
+	roleARN := fmt.Sprintf("arn:aws:iam::123456789012:role/test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_database_user.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasDatabaseUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMongodbatlasDatabaseUserAWSIAM(projectName, "not-an-arn", "ROLE"),
+				ExpectError: regexp.MustCompile("username must be the ARN of an IAM user or role"),
+			},
+			{
+				Config:      testAccMongodbatlasDatabaseUserAWSIAM(projectName, roleARN, "USER"),
+				ExpectError: regexp.MustCompile("username must be the ARN of an IAM user"),
+			},
+			{
+				Config: testAccMongodbatlasDatabaseUserAWSIAM(projectName, roleARN, "ROLE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasDatabaseUserExists(resourceName, &databaseUser),
+					resource.TestCheckResourceAttr(resourceName, "username", roleARN),
+					resource.TestCheckResourceAttr(resourceName, "database", "$external"),
+					resource.TestCheckResourceAttr(resourceName, "aws_iam_type", "ROLE"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateIdFunc: testAccMongodbatlasDatabaseUserImportStateID(resourceName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMongodbatlasDatabaseUser_parseImportID(t *testing.T) {
+	cases := []struct {
+		id       string
+		gid      string
+		database string
+		username string
+		err      bool
+	}{
+		{id: "5ba8c5c396e8211ae8272486/admin/my-user", gid: "5ba8c5c396e8211ae8272486", database: "admin", username: "my-user"},
+		{id: "5ba8c5c396e8211ae8272486/reporting/my-user", gid: "5ba8c5c396e8211ae8272486", database: "reporting", username: "my-user"},
+		{id: "5ba8c5c396e8211ae8272486/$external/arn:aws:iam::123456789012:role/path/to/role", gid: "5ba8c5c396e8211ae8272486", database: "$external", username: "arn:aws:iam::123456789012:role/path/to/role"},
+		{id: "5ba8c5c396e8211ae8272486//my-user", err: true},
+		{id: "5ba8c5c396e8211ae8272486/admin/", err: true},
+		{id: "5ba8c5c396e8211ae8272486-my-user", gid: "5ba8c5c396e8211ae8272486", database: "admin", username: "my-user"},
+		{id: "5ba8c5c396e8211ae8272486-arn:aws:iam::123456789012:role/app-role", gid: "5ba8c5c396e8211ae8272486", database: "$external", username: "arn:aws:iam::123456789012:role/app-role"},
+		{id: "5ba8c5c396e8211ae8272486-arn:aws-us-gov:iam::123456789012:user/path/to/user", gid: "5ba8c5c396e8211ae8272486", database: "$external", username: "arn:aws-us-gov:iam::123456789012:user/path/to/user"},
+		{id: "my-user", err: true},
+		{id: "5ba8c5c396e8211ae8272486-", err: true},
+		{id: "arn:aws:iam::123456789012:role/app-role", err: true},
+	}
+
+	for _, c := range cases {
+		gid, database, username, err := parseDatabaseUserImportID(c.id)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", c.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.id, err)
+			continue
+		}
+		if gid != c.gid || database != c.database || username != c.username {
+			t.Errorf("%s: expected %s, %s and %s, got %s, %s and %s", c.id, c.gid, c.database, c.username, gid, database, username)
+		}
+	}
+}
+
+func testAccMongodbatlasDatabaseUserImportStateID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["group"], rs.Primary.Attributes["database"], rs.Primary.ID), nil
+	}
+}
+
 func TestAccAWSEcsDatabaseUser_importBasic(t *testing.T) {
 	projectName := "test"
 	projectID := "5ba8c5c396e8211ae8272486"
@@ -93,9 +358,9 @@ func testAccCheckMongodbatlasDatabaseUserExists(n string, res *ma.DatabaseUser)
 			return errors.New("No DatabaseUser group ID is set")
 		}
 
-		client := testAccProvider.Meta().(*ma.Client)
+		client := testAccProvider.Meta().(*MongoDBClient).Client
 
-		c, _, err := client.DatabaseUsers.Get(rs.Primary.Attributes["group"], rs.Primary.ID)
+		c, _, err := client.DatabaseUsers.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["database"], rs.Primary.ID)
 		if err != nil {
 			return err
 		}
@@ -106,7 +371,7 @@ func testAccCheckMongodbatlasDatabaseUserExists(n string, res *ma.DatabaseUser)
 }
 
 func testAccCheckMongodbatlasDatabaseUserDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ma.Client)
+	client := testAccProvider.Meta().(*MongoDBClient).Client
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "mongodbatlas_database_user" {
 			continue
@@ -147,3 +412,272 @@ data "mongodbatlas_project" "test" {
   name = "%s"
 }`, databaseUserName, databaseUserPassword, roleName, projectName)
 }
+
+func testAccMongodbatlasDatabaseUserScopes(projectName, clusterName, databaseUserName, databaseUserPassword, scopeName string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_database_user" "test" {
+  username = "%s"
+  password = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  database = "admin"
+  roles  = [
+    {
+      name = "read"
+      database = "admin"
+    }
+  ]
+  scopes  = [
+    {
+      name = "%s"
+    }
+  ]
+}
+
+%s`, databaseUserName, databaseUserPassword, scopeName, testAccMongodbatlasDatabaseUserScopesCluster(projectName, clusterName))
+}
+
+func testAccMongodbatlasDatabaseUserScopesCluster(projectName, clusterName string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_cluster" "test" {
+  name = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name = "AWS"
+  region = "US_EAST_1"
+  size = "M10"
+  backup = false
+  disk_gb_enabled = false
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, clusterName, projectName)
+}
+
+func testAccMongodbatlasDatabaseUserDeleteAfterDate(projectName, databaseUserName, databaseUserPassword, deleteAfterDate, requester string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_database_user" "test" {
+  username = "%s"
+  password = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  database = "admin"
+  delete_after_date = "%s"
+  roles  = [
+    {
+      name = "read"
+      database = "admin"
+    }
+  ]
+  labels  = [
+    {
+      key = "requester"
+      value = "%s"
+    }
+  ]
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, databaseUserName, databaseUserPassword, deleteAfterDate, requester, projectName)
+}
+
+func testAccMongodbatlasDatabaseUserGeneratePassword(projectName, databaseUserName, rotationTrigger string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_database_user" "test" {
+  username = "%s"
+  generate_password = true
+  password_length = 24
+  rotation_trigger = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  database = "admin"
+  roles  = [
+    {
+      name = "read"
+      database = "admin"
+    }
+  ]
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, databaseUserName, rotationTrigger, projectName)
+}
+
+func testAccMongodbatlasDatabaseUserAWSIAM(projectName, username, awsIAMType string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_database_user" "test" {
+  username = "%s"
+  aws_iam_type = "%s"
+  group = "${data.mongodbatlas_project.test.id}"
+  database = "$external"
+  roles  = [
+    {
+      name = "readWrite"
+      database = "app"
+    }
+  ]
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, username, awsIAMType, projectName)
+}
+
+func TestMongodbatlasDatabaseUser_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceDatabaseUser(), map[string]interface{}{
+		"group":    "5ba8c5c396e8211ae8272486",
+		"username": "test",
+		"database": "admin",
+	}, "test")
+}
+
+func TestMongodbatlasDatabaseUser_updateOmittedFields(t *testing.T) {
+	r := resourceDatabaseUser()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username": "test",
+		"password": "new password",
+		"database": "admin",
+		"group":    "5ba8c5c396e8211ae8272486",
+		"roles": []interface{}{
+			map[string]interface{}{"name": "read", "database": "admin"},
+		},
+	})
+	d.SetId("test")
+
+	// Atlas leaves the scopes and labels out of users without them
+	user := `{"username":"test","databaseName":"admin","groupId":"5ba8c5c396e8211ae8272486","roles":[{"databaseName":"admin","roleName":"read"}]}`
+	var updateBody string
+	httpClient := &http.Client{Transport: testRoundTripper(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(req.Body)
+			updateBody = string(body)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(user)),
+			Request:    req,
+		}
+	})}
+	meta := &MongoDBClient{Client: ma.NewClient(httpClient)}
+
+	if err := r.Update(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(updateBody, `"scopes":null`) {
+		t.Fatalf("expected scopes not to be sent as null, got %s", updateBody)
+	}
+	if strings.Contains(updateBody, `"labels":null`) {
+		t.Fatalf("expected labels not to be sent as null, got %s", updateBody)
+	}
+}
+
+func TestMongodbatlasDatabaseUser_scopesOrder(t *testing.T) {
+	r := resourceDatabaseUser()
+	scopes := r.Schema["scopes"].Elem.(*schema.Resource)
+	cluster := schema.HashResource(scopes)(map[string]interface{}{"name": "cluster0", "type": "CLUSTER"})
+	lake := schema.HashResource(scopes)(map[string]interface{}{"name": "lake0", "type": "DATA_LAKE"})
+	role := schema.HashResource(r.Schema["roles"].Elem.(*schema.Resource))(map[string]interface{}{"name": "readWrite", "database": "app", "collection": ""})
+
+	attributes := map[string]string{
+		"id":                                     "test",
+		"group":                                  "5ba8c5c396e8211ae8272486",
+		"username":                               "test",
+		"database":                               "admin",
+		"aws_iam_type":                           "NONE",
+		"generate_password":                      "false",
+		"password_length":                        "32",
+		"password_charset":                       "alphanumeric",
+		"roles.#":                                "1",
+		fmt.Sprintf("roles.%d.name", role):       "readWrite",
+		fmt.Sprintf("roles.%d.database", role):   "app",
+		fmt.Sprintf("roles.%d.collection", role): "",
+		"scopes.#":                               "2",
+		fmt.Sprintf("scopes.%d.name", cluster):   "cluster0",
+		fmt.Sprintf("scopes.%d.type", cluster):   "CLUSTER",
+		fmt.Sprintf("scopes.%d.name", lake):      "lake0",
+		fmt.Sprintf("scopes.%d.type", lake):      "DATA_LAKE",
+	}
+	raw := map[string]interface{}{
+		"group":    "5ba8c5c396e8211ae8272486",
+		"username": "test",
+		"database": "admin",
+		"roles": []interface{}{
+			map[string]interface{}{"name": "readWrite", "database": "app"},
+		},
+		"scopes": []interface{}{
+			map[string]interface{}{"name": "lake0", "type": "DATA_LAKE"},
+			map[string]interface{}{"name": "cluster0"},
+		},
+	}
+
+	// Reordering the scopes doesn't change them, so Atlas isn't even asked about the clusters
+	diff, err := testResourceDiff(t, r, "test", attributes, raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected no diff, got %#v", diff)
+	}
+}
+
+func TestMongodbatlasDatabaseUser_removeDeleteAfterDate(t *testing.T) {
+	r := resourceDatabaseUser()
+	role := schema.HashResource(r.Schema["roles"].Elem.(*schema.Resource))(map[string]interface{}{"name": "read", "database": "admin", "collection": ""})
+	state := &terraform.InstanceState{
+		ID: "test",
+		Attributes: map[string]string{
+			"id":                                     "test",
+			"group":                                  "5ba8c5c396e8211ae8272486",
+			"username":                               "test",
+			"database":                               "admin",
+			"aws_iam_type":                           "NONE",
+			"generate_password":                      "false",
+			"password_length":                        "32",
+			"password_charset":                       "alphanumeric",
+			"delete_after_date":                      "2019-10-21T18:00:00Z",
+			"roles.#":                                "1",
+			fmt.Sprintf("roles.%d.name", role):       "read",
+			fmt.Sprintf("roles.%d.database", role):   "admin",
+			fmt.Sprintf("roles.%d.collection", role): "",
+		},
+	}
+	raw := map[string]interface{}{
+		"group":    "5ba8c5c396e8211ae8272486",
+		"username": "test",
+		"database": "admin",
+		"roles": []interface{}{
+			map[string]interface{}{"name": "read", "database": "admin"},
+		},
+	}
+
+	user := `{"username":"test","databaseName":"admin","groupId":"5ba8c5c396e8211ae8272486","deleteAfterDate":"2019-10-21T18:00:00Z","roles":[{"databaseName":"admin","roleName":"read"}]}`
+	var updateBody string
+	httpClient := &http.Client{Transport: testRoundTripper(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(req.Body)
+			updateBody = string(body)
+			user = `{"username":"test","databaseName":"admin","groupId":"5ba8c5c396e8211ae8272486","roles":[{"databaseName":"admin","roleName":"read"}]}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(user)),
+			Request:    req,
+		}
+	})}
+	meta := &MongoDBClient{Client: ma.NewClient(httpClient)}
+
+	diff, err := testResourceDiff(t, r, state.ID, state.Attributes, raw, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("expected removing delete_after_date not to replace the user")
+	}
+	newState, err := r.Apply(state, diff, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(updateBody, `"deleteAfterDate":""`) {
+		t.Fatalf("expected deleteAfterDate to be cleared, got %s", updateBody)
+	}
+	if date := newState.Attributes["delete_after_date"]; date != "" {
+		t.Fatalf("expected no delete_after_date, got %s", date)
+	}
+}
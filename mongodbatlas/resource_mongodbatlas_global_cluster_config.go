@@ -0,0 +1,282 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGlobalClusterConfig() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceGlobalClusterConfigCreate,
+		Read:          resourceGlobalClusterConfigRead,
+		Update:        resourceGlobalClusterConfigUpdate,
+		Delete:        resourceGlobalClusterConfigDelete,
+		CustomizeDiff: resourceGlobalClusterConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceGlobalClusterConfigImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"managed_namespaces": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"db": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"collection": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"custom_shard_key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"custom_zone_mappings": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"location": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"zone": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"custom_zone_mapping": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceGlobalClusterConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	c, _, err := client.Clusters.Get(group, clusterName)
+	if err != nil {
+		return fmt.Errorf("Error reading MongoDB Cluster %s: %s", clusterName, err)
+	}
+
+	for _, n := range readManagedNamespacesFromSchema(d.Get("managed_namespaces").(*schema.Set).List()) {
+		namespace := n
+		_, _, err := client.GlobalClusters.AddManagedNamespace(group, clusterName, &namespace)
+		if err != nil {
+			return fmt.Errorf("Error adding managed namespace %s.%s to MongoDB Global Cluster %s: %s", n.Db, n.Collection, clusterName, err)
+		}
+	}
+
+	mappings := readCustomZoneMappingsFromSchema(d.Get("custom_zone_mappings").(*schema.Set).List())
+	if len(mappings) > 0 {
+		_, _, err := client.GlobalClusters.AddCustomZoneMappings(group, clusterName, mappings)
+		if err != nil {
+			return fmt.Errorf("Error adding custom zone mappings to MongoDB Global Cluster %s: %s", clusterName, err)
+		}
+	}
+
+	d.SetId(c.ID)
+	log.Printf("[INFO] MongoDB Global Cluster Config ID: %s", d.Id())
+
+	return resourceGlobalClusterConfigRead(d, meta)
+}
+
+func resourceGlobalClusterConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+
+	g, resp, err := client.GlobalClusters.Get(d.Get("group").(string), d.Get("cluster_name").(string))
+	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Global Cluster %s not found, removing from state", d.Get("cluster_name").(string))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading MongoDB Global Cluster %s: %s", d.Get("cluster_name").(string), err)
+	}
+
+	namespaces := make([]map[string]interface{}, len(g.ManagedNamespaces))
+	for i, n := range g.ManagedNamespaces {
+		namespaces[i] = map[string]interface{}{
+			"db":               n.Db,
+			"collection":       n.Collection,
+			"custom_shard_key": n.CustomShardKey,
+		}
+	}
+	if err := d.Set("managed_namespaces", namespaces); err != nil {
+		log.Printf("[WARN] Error setting managed_namespaces for (%s): %s", d.Id(), err)
+	}
+
+	// Atlas only returns the zone ID of each location, so keep the configured
+	// zone names of the locations which are still mapped.
+	mappings := []interface{}{}
+	for _, m := range d.Get("custom_zone_mappings").(*schema.Set).List() {
+		mapping := m.(map[string]interface{})
+		if _, ok := g.CustomZoneMapping[mapping["location"].(string)]; ok {
+			mappings = append(mappings, mapping)
+		}
+	}
+	if err := d.Set("custom_zone_mappings", mappings); err != nil {
+		log.Printf("[WARN] Error setting custom_zone_mappings for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("custom_zone_mapping", g.CustomZoneMapping); err != nil {
+		log.Printf("[WARN] Error setting custom_zone_mapping for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceGlobalClusterConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	if d.HasChange("managed_namespaces") {
+		// Removing a namespace forces a new resource, so only additions are left here
+		o, n := d.GetChange("managed_namespaces")
+		added := n.(*schema.Set).Difference(o.(*schema.Set)).List()
+		for _, n := range readManagedNamespacesFromSchema(added) {
+			namespace := n
+			_, _, err := client.GlobalClusters.AddManagedNamespace(group, clusterName, &namespace)
+			if err != nil {
+				return fmt.Errorf("Error adding managed namespace %s.%s to MongoDB Global Cluster %s: %s", n.Db, n.Collection, clusterName, err)
+			}
+		}
+	}
+
+	if d.HasChange("custom_zone_mappings") {
+		_, _, err := client.GlobalClusters.DeleteCustomZoneMappings(group, clusterName)
+		if err != nil {
+			return fmt.Errorf("Error deleting custom zone mappings of MongoDB Global Cluster %s: %s", clusterName, err)
+		}
+
+		mappings := readCustomZoneMappingsFromSchema(d.Get("custom_zone_mappings").(*schema.Set).List())
+		if len(mappings) > 0 {
+			_, _, err := client.GlobalClusters.AddCustomZoneMappings(group, clusterName, mappings)
+			if err != nil {
+				return fmt.Errorf("Error adding custom zone mappings to MongoDB Global Cluster %s: %s", clusterName, err)
+			}
+		}
+	}
+
+	return resourceGlobalClusterConfigRead(d, meta)
+}
+
+func resourceGlobalClusterConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	log.Printf("[DEBUG] MongoDB Global Cluster Config destroy: %v", d.Id())
+	for _, n := range readManagedNamespacesFromSchema(d.Get("managed_namespaces").(*schema.Set).List()) {
+		namespace := n
+		_, _, err := client.GlobalClusters.DeleteManagedNamespace(group, clusterName, &namespace)
+		if err != nil {
+			return fmt.Errorf("Error deleting managed namespace %s.%s from MongoDB Global Cluster %s: %s", n.Db, n.Collection, clusterName, err)
+		}
+	}
+
+	if d.Get("custom_zone_mappings").(*schema.Set).Len() > 0 {
+		_, _, err := client.GlobalClusters.DeleteCustomZoneMappings(group, clusterName)
+		if err != nil {
+			return fmt.Errorf("Error deleting custom zone mappings of MongoDB Global Cluster %s: %s", clusterName, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceGlobalClusterConfigImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*MongoDBClient).Client
+
+	parts := strings.SplitN(d.Id(), "-", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("To import a global cluster config, use the format {group id}-{cluster name}")
+	}
+	gid := parts[0]
+	name := parts[1]
+
+	c, _, err := client.Clusters.Get(gid, name)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't import global cluster config of cluster %s in group %s, error: %s", name, gid, err.Error())
+	}
+
+	d.SetId(c.ID)
+	if err := d.Set("group", c.GroupID); err != nil {
+		log.Printf("[WARN] Error setting group for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("cluster_name", c.Name); err != nil {
+		log.Printf("[WARN] Error setting cluster_name for (%s): %s", d.Id(), err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// Managed namespaces are append-only in Atlas, so removing one has to replace the resource
+func resourceGlobalClusterConfigCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("managed_namespaces") {
+		return nil
+	}
+
+	o, n := d.GetChange("managed_namespaces")
+	if o.(*schema.Set).Difference(n.(*schema.Set)).Len() > 0 {
+		return d.ForceNew("managed_namespaces")
+	}
+	return nil
+}
+
+func readManagedNamespacesFromSchema(namespacesMap []interface{}) (namespaces []ma.ManagedNamespace) {
+	namespaces = make([]ma.ManagedNamespace, len(namespacesMap))
+	for i, n := range namespacesMap {
+		namespaceMap := n.(map[string]interface{})
+
+		namespaces[i] = ma.ManagedNamespace{
+			Db:             namespaceMap["db"].(string),
+			Collection:     namespaceMap["collection"].(string),
+			CustomShardKey: namespaceMap["custom_shard_key"].(string),
+		}
+	}
+	return namespaces
+}
+
+func readCustomZoneMappingsFromSchema(mappingsMap []interface{}) (mappings []ma.CustomZoneMapping) {
+	mappings = make([]ma.CustomZoneMapping, len(mappingsMap))
+	for i, m := range mappingsMap {
+		mappingMap := m.(map[string]interface{})
+
+		mappings[i] = ma.CustomZoneMapping{
+			Location: mappingMap["location"].(string),
+			Zone:     mappingMap["zone"].(string),
+		}
+	}
+	return mappings
+}
@@ -0,0 +1,165 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMongodbatlasGlobalClusterConfig_basic(t *testing.T) {
+	var globalCluster ma.GlobalCluster
+	projectName := "test"
+	clusterName := fmt.Sprintf("test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resourceName := "mongodbatlas_global_cluster_config.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasGlobalClusterConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasGlobalClusterConfig(projectName, clusterName, "CA", "Zone 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasGlobalClusterConfigExists(resourceName, &globalCluster),
+					resource.TestCheckResourceAttrSet(resourceName, "group"),
+					resource.TestCheckResourceAttr(resourceName, "cluster_name", clusterName),
+					resource.TestCheckResourceAttr(resourceName, "managed_namespaces.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_zone_mappings.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "custom_zone_mapping.CA"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasGlobalClusterConfig(projectName, clusterName, "US", "Zone 2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasGlobalClusterConfigExists(resourceName, &globalCluster),
+					resource.TestCheckResourceAttr(resourceName, "custom_zone_mappings.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "custom_zone_mapping.US"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMongodbatlasGlobalClusterConfigExists(n string, res *ma.GlobalCluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Global Cluster Config ID is set")
+		}
+
+		if rs.Primary.Attributes["cluster_name"] == "" {
+			return errors.New("No Global Cluster Config cluster name is set")
+		}
+
+		client := testAccProvider.Meta().(*MongoDBClient).Client
+
+		g, _, err := client.GlobalClusters.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["cluster_name"])
+		if err != nil {
+			return err
+		}
+
+		if len(g.ManagedNamespaces) == 0 {
+			return fmt.Errorf("Global Cluster %q has no managed namespaces", rs.Primary.Attributes["cluster_name"])
+		}
+
+		*res = *g
+		return nil
+	}
+}
+
+func testAccCheckMongodbatlasGlobalClusterConfigDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*MongoDBClient).Client
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodbatlas_global_cluster_config" {
+			continue
+		}
+
+		g, resp, err := client.GlobalClusters.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["cluster_name"])
+
+		if err == nil {
+			if len(g.ManagedNamespaces) != 0 || len(g.CustomZoneMapping) != 0 {
+				return fmt.Errorf("Global Cluster Config %q still exists", rs.Primary.ID)
+			}
+		}
+
+		// Verify the error
+		if err != nil && resp.StatusCode != 404 {
+			return fmt.Errorf("Error reading MongoDB Global Cluster: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccMongodbatlasGlobalClusterConfig(projectName, clusterName, location, zone string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_global_cluster_config" "test" {
+  group        = "${data.mongodbatlas_project.test.id}"
+  cluster_name = "${mongodbatlas_cluster.test.name}"
+
+  managed_namespaces {
+    db               = "mydata"
+    collection       = "publishers"
+    custom_shard_key = "city"
+  }
+
+  custom_zone_mappings {
+    location = "%s"
+    zone     = "%s"
+  }
+}
+
+resource "mongodbatlas_cluster" "test" {
+  name                  = "%s"
+  group                 = "${data.mongodbatlas_project.test.id}"
+  mongodb_major_version = "4.0"
+  provider_name         = "AWS"
+  region                = ""
+  size                  = "M30"
+  backup                = false
+  disk_gb_enabled       = false
+  cluster_type          = "GEOSHARDED"
+
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 1
+
+    regions_config {
+      region          = "US_EAST_1"
+      priority        = 7
+      electable_nodes = 3
+    }
+  }
+
+  replication_specs {
+    zone_name  = "Zone 2"
+    num_shards = 1
+
+    regions_config {
+      region          = "EU_WEST_1"
+      priority        = 7
+      electable_nodes = 3
+    }
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, location, zone, clusterName, projectName)
+}
+
+func TestMongodbatlasGlobalClusterConfig_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceGlobalClusterConfig(), map[string]interface{}{
+		"group":        "5ba8c5c396e8211ae8272486",
+		"cluster_name": "test",
+	}, "5ba8c5c396e8211ae8272487")
+}
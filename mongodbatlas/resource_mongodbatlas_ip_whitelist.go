@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"strings"
 	"sync"
+	"time"
 
 	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -23,6 +25,7 @@ func resourceIPWhitelist() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceIPWhiteListImportState,
 		},
+		CustomizeDiff: resourceIPWhitelistCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"group": {
@@ -35,20 +38,46 @@ func resourceIPWhitelist() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"ip_address"},
+				ConflictsWith: []string{"ip_address", "aws_security_group"},
+				ValidateFunc:  validateWhitelistCidrBlock,
+				StateFunc:     canonicalWhitelistAddress(canonicalCidrBlock),
 			},
 			"ip_address": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Computed:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"cidr_block"},
+				ConflictsWith: []string{"cidr_block", "aws_security_group"},
+				ValidateFunc:  validateWhitelistIPAddress,
+				StateFunc:     canonicalWhitelistAddress(canonicalIPAddress),
+			},
+			"aws_security_group": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cidr_block", "ip_address"},
 			},
 			"comment": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringLenBetween(0, 80),
 			},
+			"delete_after_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"duration"},
+				ValidateFunc:     validateDeleteAfterDate,
+				DiffSuppressFunc: suppressEquivalentRFC3339,
+			},
+			"duration": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"delete_after_date"},
+				ValidateFunc:  validateWhitelistDuration,
+			},
 		},
 	}
 }
@@ -57,9 +86,16 @@ func resourceIPWhitelistCreate(d *schema.ResourceData, meta interface{}) error {
 	apiMutex.Lock()
 	defer apiMutex.Unlock()
 
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	cidrBlock := d.Get("cidr_block").(string)
 	ip := d.Get("ip_address").(string)
+	securityGroup := d.Get("aws_security_group").(string)
+	deleteAfterDate := d.Get("delete_after_date").(string)
+
+	// The duration is only turned into a date when the entry is created, updates keep the date
+	if duration := d.Get("duration").(string); duration != "" && d.Id() == "" {
+		deleteAfterDate = whitelistDeleteAfterDate(time.Now(), duration)
+	}
 
 	if cidrBlock != "" && ip != "" {
 		// cidrBlock & ip are mutually exclusive, use cidrBlock if both are set
@@ -68,10 +104,12 @@ func resourceIPWhitelistCreate(d *schema.ResourceData, meta interface{}) error {
 
 	params := []ma.Whitelist{
 		{
-			CidrBlock: cidrBlock,
-			GroupID:   d.Get("group").(string),
-			IPAddress: ip,
-			Comment:   d.Get("comment").(string),
+			CidrBlock:        cidrBlock,
+			GroupID:          d.Get("group").(string),
+			IPAddress:        ip,
+			AwsSecurityGroup: securityGroup,
+			Comment:          d.Get("comment").(string),
+			DeleteAfterDate:  deleteAfterDate,
 		},
 	}
 
@@ -80,31 +118,52 @@ func resourceIPWhitelistCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Error creating MongoDB Project IP Whitelist: %s", err)
 	}
+	// Atlas may write addresses differently than configured, e.g. IPv6 ones, so entries are
+	// matched and identified by their canonical CIDR block
+	id := whitelistEntryID(params[0])
 	for _, w := range whitelists {
-		if (cidrBlock != "" && w.CidrBlock == cidrBlock) || (ip != "" && w.IPAddress == ip) {
-			d.SetId(w.CidrBlock)
+		if whitelistEntryID(w) == id {
+			d.SetId(id)
 			log.Printf("[INFO] MongoDB Project IP Whitelist ID: %s", d.Id())
 
 			return resourceIPWhitelistRead(d, meta)
 		}
 	}
-	return fmt.Errorf("MongoDB Project IP Whitelist with CIDR block: %s and IP Address: %s could not be found in the response from MongoDB Atlas", cidrBlock, ip)
+	return fmt.Errorf("MongoDB Project IP Whitelist with CIDR block: %s, IP Address: %s and AWS security group: %s could not be found in the response from MongoDB Atlas", cidrBlock, ip, securityGroup)
 }
 
 func resourceIPWhitelistRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
-	w, _, err := client.Whitelist.Get(d.Get("group").(string), d.Id())
+	w, resp, err := client.Whitelist.Get(d.Get("group").(string), d.Id())
 	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Project IP Whitelist %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading MongoDB Project IP Whitelist %s: %s", d.Id(), err)
 	}
 
-	if err := d.Set("cidr_block", w.CidrBlock); err != nil {
+	// Atlas may still return entries for a little while after their delete_after_date
+	if date, err := time.Parse(time.RFC3339, w.DeleteAfterDate); err == nil && date.Before(time.Now()) {
+		log.Printf("[WARN] MongoDB Project IP Whitelist %s expired on %s, removing from state", d.Id(), w.DeleteAfterDate)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("cidr_block", canonicalWhitelistAddress(canonicalCidrBlock)(w.CidrBlock)); err != nil {
 		log.Printf("[WARN] Error setting cidr_block for (%s): %s", d.Id(), err)
 	}
-	if err := d.Set("ip_address", w.IPAddress); err != nil {
+	if err := d.Set("ip_address", canonicalWhitelistAddress(canonicalIPAddress)(w.IPAddress)); err != nil {
 		log.Printf("[WARN] Error setting ip_address for (%s): %s", d.Id(), err)
 	}
+	if err := d.Set("aws_security_group", w.AwsSecurityGroup); err != nil {
+		log.Printf("[WARN] Error setting aws_security_group for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("delete_after_date", w.DeleteAfterDate); err != nil {
+		log.Printf("[WARN] Error setting delete_after_date for (%s): %s", d.Id(), err)
+	}
 	if err := d.Set("group", w.GroupID); err != nil {
 		log.Printf("[WARN] Error setting group for (%s): %s", d.Id(), err)
 	}
@@ -123,11 +182,15 @@ func resourceIPWhitelistDelete(d *schema.ResourceData, meta interface{}) error {
 	apiMutex.Lock()
 	defer apiMutex.Unlock()
 
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	log.Printf("[DEBUG] MongoDB Project IP Whitelist destroy: %v", d.Id())
-	_, err := client.Whitelist.Delete(d.Get("group").(string), d.Id())
+	resp, err := client.Whitelist.Delete(d.Get("group").(string), d.Id())
 	if err != nil {
+		// The entry may have expired since it was last read
+		if isNotFound(resp) {
+			return nil
+		}
 		return fmt.Errorf("Error destroying MongoDB Project IP Whitelist %s: %s", d.Id(), err)
 	}
 
@@ -135,24 +198,146 @@ func resourceIPWhitelistDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceIPWhiteListImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	parts := strings.SplitN(d.Id(), "-", 2)
 	if len(parts) != 2 {
-		return nil, errors.New("To import an ip whitelist, use the format {group id}-{cidr block}")
+		return nil, errors.New("To import an ip whitelist, use the format {group id}-{cidr block}, {group id}-{ip address} or {group id}-{aws security group}")
 	}
 	gid := parts[0]
 	cidr := parts[1]
+	if c, err := canonicalCidrBlock(cidr); err == nil {
+		cidr = c
+	}
 
 	ip, _, err := client.Whitelist.Get(gid, cidr)
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't import ip whitelist %s in group %s, error: %s", cidr, gid, err.Error())
 	}
 
-	d.SetId(ip.CidrBlock)
+	d.SetId(whitelistEntryID(*ip))
 	if err := d.Set("group", ip.GroupID); err != nil {
 		log.Printf("[WARN] Error setting group for (%s): %s", d.Id(), err)
 	}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// resourceIPWhitelistCustomizeDiff checks that AWS security groups can be whitelisted,
+// which Atlas only allows for projects peered with an AWS VPC.
+func resourceIPWhitelistCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("aws_security_group").(string) == "" || !d.HasChange("aws_security_group") || !d.NewValueKnown("group") {
+		return nil
+	}
+	return validateAWSPeering(meta.(*MongoDBClient).Client, d.Get("group").(string))
+}
+
+func validateAWSPeering(client *ma.Client, group string) error {
+	peers, _, err := client.Peers.List(group, "AWS")
+	if err != nil {
+		return fmt.Errorf("Error listing MongoDB VPC Peering connections of group %s: %s", group, err)
+	}
+	for _, p := range peers {
+		if p.StatusName == "AVAILABLE" {
+			return nil
+		}
+	}
+	return fmt.Errorf("aws_security_group: group %s has no available AWS VPC peering connection, which Atlas requires to whitelist security groups", group)
+}
+
+func validateWhitelistDuration(v interface{}, k string) (ws []string, errors []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration, e.g. 8h or 30m: %s", k, err))
+		return
+	}
+	if duration <= 0 {
+		errors = append(errors, fmt.Errorf("%q must be positive, got %s", k, v.(string)))
+	}
+	return
+}
+
+// whitelistDeleteAfterDate returns the date, duration after now, when the entry expires
+func whitelistDeleteAfterDate(now time.Time, duration string) string {
+	d, _ := time.ParseDuration(duration)
+	return now.Add(d).UTC().Format(time.RFC3339)
+}
+
+// canonicalCidrBlock returns the network of a CIDR block, e.g. 10.0.0.0/24 for 10.0.0.1/24,
+// or the block of a single IPv4 or IPv6 address.
+func canonicalCidrBlock(address string) (string, error) {
+	if !strings.Contains(address, "/") {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return "", fmt.Errorf("%s is not an IP address", address)
+		}
+		if ip.To4() != nil {
+			return ip.String() + "/32", nil
+		}
+		return ip.String() + "/128", nil
+	}
+
+	_, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return "", err
+	}
+	return network.String(), nil
+}
+
+func canonicalIPAddress(address string) (string, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", fmt.Errorf("%s is not an IP address", address)
+	}
+	return ip.String(), nil
+}
+
+// canonicalWhitelistAddress returns a StateFunc storing addresses in their canonical form.
+// Invalid addresses are kept as they are, their ValidateFunc rejects them.
+func canonicalWhitelistAddress(canonical func(string) (string, error)) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		address := v.(string)
+		if address == "" {
+			return ""
+		}
+		c, err := canonical(address)
+		if err != nil {
+			return address
+		}
+		return c
+	}
+}
+
+func validateWhitelistCidrBlock(v interface{}, k string) (ws []string, errors []error) {
+	if _, _, err := net.ParseCIDR(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a CIDR block, e.g. 10.0.0.0/24 or 2001:db8::/32: %s", k, err))
+		return
+	}
+	if c, _ := canonicalCidrBlock(v.(string)); c != v.(string) {
+		ws = append(ws, fmt.Sprintf("%q %s is not the address of its network, Atlas whitelists %s", k, v.(string), c))
+	}
+	return
+}
+
+func validateWhitelistIPAddress(v interface{}, k string) (ws []string, errors []error) {
+	if net.ParseIP(v.(string)) == nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 or IPv6 address, got %s", k, v.(string)))
+	}
+	return
+}
+
+// whitelistEntryID identifies an entry by its AWS security group, or by the canonical CIDR
+// block of its address, which doesn't depend on how it was configured or returned by Atlas.
+func whitelistEntryID(w ma.Whitelist) string {
+	if w.AwsSecurityGroup != "" {
+		return w.AwsSecurityGroup
+	}
+	address := w.CidrBlock
+	if address == "" {
+		address = w.IPAddress
+	}
+	if c, err := canonicalCidrBlock(address); err == nil {
+		return c
+	}
+	return address
+}
@@ -3,10 +3,13 @@ package mongodbatlas
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -45,6 +48,30 @@ func TestAccMongodbatlasIPWhitelist_basic(t *testing.T) {
 	})
 }
 
+func TestAccMongodbatlasIPWhitelist_duration(t *testing.T) {
+	var whitelist ma.Whitelist
+	projectName := "test"
+	cidrBlock := "179.154.224.128/32"
+
+	resourceName := "mongodbatlas_ip_whitelist.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasWhitelistDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasWhitelistDuration(projectName, cidrBlock, "8h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasWhitelistExists(resourceName, &whitelist),
+					resource.TestCheckResourceAttr(resourceName, "duration", "8h"),
+					resource.TestCheckResourceAttrSet(resourceName, "delete_after_date"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSEcsWhitelist_importBasic(t *testing.T) {
 	projectName := "test"
 	projectID := "5ba8c5c396e8211ae8272486"
@@ -68,6 +95,12 @@ func TestAccAWSEcsWhitelist_importBasic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     fmt.Sprintf("%s-%s", projectID, "179.154.224.127"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -91,7 +124,7 @@ func testAccCheckMongodbatlasWhitelistExists(n string, res *ma.Whitelist) resour
 			return errors.New("No Whitelist CIDR Block is set")
 		}
 
-		client := testAccProvider.Meta().(*ma.Client)
+		client := testAccProvider.Meta().(*MongoDBClient).Client
 
 		c, _, err := client.Whitelist.Get(rs.Primary.Attributes["group"], rs.Primary.Attributes["cidr_block"])
 		if err != nil {
@@ -104,7 +137,7 @@ func testAccCheckMongodbatlasWhitelistExists(n string, res *ma.Whitelist) resour
 }
 
 func testAccCheckMongodbatlasWhitelistDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ma.Client)
+	client := testAccProvider.Meta().(*MongoDBClient).Client
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "mongodbatlas_ip_whitelist" {
 			continue
@@ -138,3 +171,165 @@ data "mongodbatlas_project" "test" {
   name = "%s"
 }`, cidrBlock, comment, projectName)
 }
+
+func testAccMongodbatlasWhitelistDuration(projectName, cidrBlock, duration string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_ip_whitelist" "test" {
+  group = "${data.mongodbatlas_project.test.id}"
+  cidr_block = "%s"
+  duration = "%s"
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, cidrBlock, duration, projectName)
+}
+
+func TestMongodbatlasIPWhitelist_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceIPWhitelist(), map[string]interface{}{
+		"group":      "5ba8c5c396e8211ae8272486",
+		"cidr_block": "10.0.0.0/16",
+	}, "10.0.0.0/16")
+}
+
+func TestMongodbatlasIPWhitelist_readSecurityGroup(t *testing.T) {
+	r := resourceIPWhitelist()
+	d := r.TestResourceData()
+	d.SetId("sg-0123456789abcdef0")
+	meta := testOfflineClient(http.StatusOK, `{"awsSecurityGroup":"sg-0123456789abcdef0","comment":"app tier","groupId":"5ba8c5c396e8211ae8272486"}`)
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if sg := d.Get("aws_security_group").(string); sg != "sg-0123456789abcdef0" {
+		t.Errorf("expected aws_security_group sg-0123456789abcdef0, got %q", sg)
+	}
+	if cidr := d.Get("cidr_block").(string); cidr != "" {
+		t.Errorf("expected no cidr_block, got %q", cidr)
+	}
+}
+
+func TestMongodbatlasIPWhitelist_validateAWSPeering(t *testing.T) {
+	cases := []struct {
+		body  string
+		valid bool
+	}{
+		{`{"results":[{"id":"1","statusName":"PENDING_ACCEPTANCE"},{"id":"2","statusName":"AVAILABLE"}],"totalCount":2}`, true},
+		{`{"results":[{"id":"1","statusName":"PENDING_ACCEPTANCE"}],"totalCount":1}`, false},
+		{`{"results":[],"totalCount":0}`, false},
+	}
+
+	for _, c := range cases {
+		client := testOfflineClient(http.StatusOK, c.body).Client
+		if err := validateAWSPeering(client, "5ba8c5c396e8211ae8272486"); (err == nil) != c.valid {
+			t.Errorf("%s: expected valid to be %t, got error %v", c.body, c.valid, err)
+		}
+	}
+}
+
+func TestMongodbatlasIPWhitelist_readExpired(t *testing.T) {
+	r := resourceIPWhitelist()
+	d := r.TestResourceData()
+	d.SetId("10.0.0.0/16")
+	meta := testOfflineClient(http.StatusOK, `{"cidrBlock":"10.0.0.0/16","deleteAfterDate":"2019-10-21T18:00:00Z","groupId":"5ba8c5c396e8211ae8272486"}`)
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the expired entry to be removed from the state, got ID %q", d.Id())
+	}
+}
+
+func TestMongodbatlasIPWhitelist_validateDuration(t *testing.T) {
+	cases := []struct {
+		duration string
+		valid    bool
+	}{
+		{"8h", true},
+		{"1h30m", true},
+		{"0s", false},
+		{"-1h", false},
+		{"1 day", false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateWhitelistDuration(c.duration, "duration")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("%s: expected valid to be %t, got errors %v", c.duration, c.valid, errs)
+		}
+	}
+}
+
+func TestMongodbatlasIPWhitelist_deleteAfterDate(t *testing.T) {
+	now := time.Date(2019, 10, 21, 20, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	if date := whitelistDeleteAfterDate(now, "1h30m"); date != "2019-10-21T19:30:00Z" {
+		t.Errorf("expected delete_after_date 2019-10-21T19:30:00Z, got %s", date)
+	}
+}
+
+func TestMongodbatlasIPWhitelist_canonicalCidrBlock(t *testing.T) {
+	cases := []struct {
+		address   string
+		canonical string
+	}{
+		{"10.0.0.1", "10.0.0.1/32"},
+		{"10.0.0.1/32", "10.0.0.1/32"},
+		{"10.0.0.1/24", "10.0.0.0/24"},
+		{"2001:DB8:0:0::1", "2001:db8::1/128"},
+		{"2001:db8::1/32", "2001:db8::/32"},
+		{"not an address", ""},
+		{"10.0.0.0/33", ""},
+	}
+
+	for _, c := range cases {
+		canonical, err := canonicalCidrBlock(c.address)
+		if c.canonical == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", c.address, canonical)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.address, err)
+		} else if canonical != c.canonical {
+			t.Errorf("%s: expected %s, got %s", c.address, c.canonical, canonical)
+		}
+	}
+}
+
+func TestMongodbatlasIPWhitelist_validateCidrBlock(t *testing.T) {
+	cases := []struct {
+		cidrBlock string
+		warnings  int
+		errors    int
+	}{
+		{"10.0.0.0/24", 0, 0},
+		{"2001:db8::/32", 0, 0},
+		{"10.0.0.1/24", 1, 0},
+		{"10.0.0.1", 0, 1},
+	}
+
+	for _, c := range cases {
+		ws, errs := validateWhitelistCidrBlock(c.cidrBlock, "cidr_block")
+		if len(ws) != c.warnings || len(errs) != c.errors {
+			t.Errorf("%s: expected %d warnings and %d errors, got %v and %v", c.cidrBlock, c.warnings, c.errors, ws, errs)
+		}
+	}
+}
+
+func TestMongodbatlasIPWhitelist_createIPv6(t *testing.T) {
+	r := resourceIPWhitelist()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group":      "5ba8c5c396e8211ae8272486",
+		"ip_address": "2001:db8::1",
+	})
+	meta := testOfflineClient(http.StatusCreated, `{"results":[
+		{"cidrBlock":"10.0.0.0/24","groupId":"5ba8c5c396e8211ae8272486"},
+		{"cidrBlock":"2001:0db8:0000:0000:0000:0000:0000:0001/128","ipAddress":"2001:0db8:0000:0000:0000:0000:0000:0001","groupId":"5ba8c5c396e8211ae8272486"}
+	],"totalCount":2}`)
+	if err := r.Create(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "2001:db8::1/128" {
+		t.Errorf("expected ID 2001:db8::1/128, got %q", d.Id())
+	}
+}
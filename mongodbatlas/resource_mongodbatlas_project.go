@@ -41,7 +41,7 @@ func resourceProject() *schema.Resource {
 }
 
 func resourceProjectCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	params := ma.Project{
 		OrgID: d.Get("org_id").(string),
@@ -59,11 +59,12 @@ func resourceProjectCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceProjectRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	p, resp, err := client.Projects.Get(d.Id())
 	if err != nil {
-		if resp.StatusCode == 404 {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Project %s not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -87,7 +88,7 @@ func resourceProjectRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceProjectDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	log.Printf("[DEBUG] MongoDB Project destroy: %v", d.Id())
 	_, err := client.Projects.Delete(d.Id())
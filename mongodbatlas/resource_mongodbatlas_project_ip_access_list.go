@@ -0,0 +1,311 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"log"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceProjectIPAccessList manages the entries of a project's IP whitelist together.
+// An authoritative access list removes the entries which aren't in the configuration,
+// otherwise entries added outside of Terraform are left alone.
+func resourceProjectIPAccessList() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectIPAccessListCreate,
+		Read:   resourceProjectIPAccessListRead,
+		Update: resourceProjectIPAccessListUpdate,
+		Delete: resourceProjectIPAccessListDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProjectIPAccessListImportState,
+		},
+		CustomizeDiff: resourceProjectIPAccessListCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"entry": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr_block": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateWhitelistCidrBlock,
+						},
+						"ip_address": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateWhitelistIPAddress,
+						},
+						"aws_security_group": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"comment": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 80),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceProjectIPAccessListCreate(d *schema.ResourceData, meta interface{}) error {
+	apiMutex.Lock()
+	defer apiMutex.Unlock()
+
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+
+	entries := readAccessListEntriesFromSchema(d.Get("entry").(*schema.Set).List())
+	if len(entries) > 0 {
+		log.Printf("[DEBUG] Creating %d MongoDB Project IP Access List entries", len(entries))
+		_, _, err := client.Whitelist.Create(group, entries)
+		if err != nil {
+			return fmt.Errorf("Error creating MongoDB Project IP Access List entries: %s", err)
+		}
+	}
+	d.SetId(group)
+	log.Printf("[INFO] MongoDB Project IP Access List ID: %s", d.Id())
+
+	if d.Get("authoritative").(bool) {
+		if err := deleteUnlistedAccessListEntries(client, group, entries); err != nil {
+			return err
+		}
+	}
+
+	return resourceProjectIPAccessListRead(d, meta)
+}
+
+func resourceProjectIPAccessListRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*MongoDBClient).Client
+
+	whitelists, resp, err := client.Whitelist.List(d.Get("group").(string))
+	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Project %s not found, removing IP Access List from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading MongoDB Project IP Access List %s: %s", d.Id(), err)
+	}
+
+	entries := flattenAccessListEntries(
+		whitelists,
+		readAccessListEntriesFromSchema(d.Get("entry").(*schema.Set).List()),
+		d.Get("authoritative").(bool),
+	)
+	if err := d.Set("entry", entries); err != nil {
+		log.Printf("[WARN] Error setting entry for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("group", d.Id()); err != nil {
+		log.Printf("[WARN] Error setting group for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceProjectIPAccessListUpdate(d *schema.ResourceData, meta interface{}) error {
+	apiMutex.Lock()
+	defer apiMutex.Unlock()
+
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+
+	o, n := d.GetChange("entry")
+	oldEntries := readAccessListEntriesFromSchema(o.(*schema.Set).List())
+	newEntries := readAccessListEntriesFromSchema(n.(*schema.Set).List())
+
+	// Entries whose comment changed are in both sets, Atlas updates them when they are created again
+	for _, w := range oldEntries {
+		if findAccessListEntry(newEntries, w) == nil {
+			if err := deleteAccessListEntry(client, group, w); err != nil {
+				return err
+			}
+		}
+	}
+
+	changed := []ma.Whitelist{}
+	for _, w := range newEntries {
+		if old := findAccessListEntry(oldEntries, w); old == nil || old.Comment != w.Comment {
+			changed = append(changed, w)
+		}
+	}
+	if len(changed) > 0 {
+		log.Printf("[DEBUG] Creating %d MongoDB Project IP Access List entries", len(changed))
+		_, _, err := client.Whitelist.Create(group, changed)
+		if err != nil {
+			return fmt.Errorf("Error creating MongoDB Project IP Access List entries: %s", err)
+		}
+	}
+
+	// The state of a list which just became authoritative doesn't hold the other entries
+	if d.Get("authoritative").(bool) {
+		if err := deleteUnlistedAccessListEntries(client, group, newEntries); err != nil {
+			return err
+		}
+	}
+
+	return resourceProjectIPAccessListRead(d, meta)
+}
+
+func resourceProjectIPAccessListDelete(d *schema.ResourceData, meta interface{}) error {
+	apiMutex.Lock()
+	defer apiMutex.Unlock()
+
+	client := meta.(*MongoDBClient).Client
+	group := d.Get("group").(string)
+
+	log.Printf("[DEBUG] MongoDB Project IP Access List destroy: %v", d.Id())
+	for _, w := range readAccessListEntriesFromSchema(d.Get("entry").(*schema.Set).List()) {
+		if err := deleteAccessListEntry(client, group, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceProjectIPAccessListImportState imports all the entries of the whitelist, which
+// Read then keeps whether or not the access list is authoritative.
+func resourceProjectIPAccessListImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*MongoDBClient).Client
+	gid := d.Id()
+
+	whitelists, _, err := client.Whitelist.List(gid)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't import ip access list of group %s, error: %s", gid, err.Error())
+	}
+
+	if err := d.Set("group", gid); err != nil {
+		log.Printf("[WARN] Error setting group for (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("entry", flattenAccessListEntries(whitelists, nil, true)); err != nil {
+		log.Printf("[WARN] Error setting entry for (%s): %s", d.Id(), err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProjectIPAccessListCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("entry") {
+		return nil
+	}
+	return validateAccessListEntries(readAccessListEntriesFromSchema(d.Get("entry").(*schema.Set).List()))
+}
+
+// validateAccessListEntries checks that each entry has exactly one address or security group
+func validateAccessListEntries(entries []ma.Whitelist) error {
+	for _, w := range entries {
+		set := 0
+		for _, v := range []string{w.CidrBlock, w.IPAddress, w.AwsSecurityGroup} {
+			if v != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("entry: exactly one of cidr_block, ip_address or aws_security_group must be set, got %d for the entry with comment %q", set, w.Comment)
+		}
+	}
+	return nil
+}
+
+func readAccessListEntriesFromSchema(entriesMap []interface{}) []ma.Whitelist {
+	entries := make([]ma.Whitelist, len(entriesMap))
+	for i, e := range entriesMap {
+		entryMap := e.(map[string]interface{})
+
+		entries[i] = ma.Whitelist{
+			CidrBlock:        entryMap["cidr_block"].(string),
+			IPAddress:        entryMap["ip_address"].(string),
+			AwsSecurityGroup: entryMap["aws_security_group"].(string),
+			Comment:          entryMap["comment"].(string),
+		}
+	}
+	return entries
+}
+
+// flattenAccessListEntries keeps the whitelist entries matching the configured ones, in the
+// form they are configured, since Atlas also returns the CIDR block of IP addresses.
+// Authoritative access lists keep the other entries too, so they show as changes to remove.
+func flattenAccessListEntries(whitelists []ma.Whitelist, configured []ma.Whitelist, authoritative bool) []map[string]interface{} {
+	entries := []map[string]interface{}{}
+	for _, w := range whitelists {
+		entry := map[string]interface{}{
+			"cidr_block":         "",
+			"ip_address":         "",
+			"aws_security_group": "",
+			"comment":            w.Comment,
+		}
+
+		c := findAccessListEntry(configured, w)
+		switch {
+		case c == nil && !authoritative:
+			continue
+		case c != nil:
+			entry["cidr_block"] = c.CidrBlock
+			entry["ip_address"] = c.IPAddress
+			entry["aws_security_group"] = c.AwsSecurityGroup
+		case w.AwsSecurityGroup != "":
+			entry["aws_security_group"] = w.AwsSecurityGroup
+		case w.IPAddress != "":
+			entry["ip_address"] = w.IPAddress
+		default:
+			entry["cidr_block"] = w.CidrBlock
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// findAccessListEntry returns the entry of entries for the same address or security group as w
+func findAccessListEntry(entries []ma.Whitelist, w ma.Whitelist) *ma.Whitelist {
+	for i, e := range entries {
+		if whitelistEntryID(e) == whitelistEntryID(w) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// deleteUnlistedAccessListEntries removes the entries of the whitelist which aren't in entries
+func deleteUnlistedAccessListEntries(client *ma.Client, group string, entries []ma.Whitelist) error {
+	whitelists, _, err := client.Whitelist.List(group)
+	if err != nil {
+		return fmt.Errorf("Error listing MongoDB Project IP Access List %s: %s", group, err)
+	}
+	for _, w := range whitelists {
+		if findAccessListEntry(entries, w) == nil {
+			if err := deleteAccessListEntry(client, group, w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteAccessListEntry(client *ma.Client, group string, w ma.Whitelist) error {
+	entry := whitelistEntryID(w)
+
+	log.Printf("[DEBUG] Deleting MongoDB Project IP Access List entry: %s", entry)
+	resp, err := client.Whitelist.Delete(group, entry)
+	if err != nil && !isNotFound(resp) {
+		return fmt.Errorf("Error deleting MongoDB Project IP Access List entry %s: %s", entry, err)
+	}
+	return nil
+}
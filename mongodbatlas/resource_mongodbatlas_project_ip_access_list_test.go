@@ -0,0 +1,230 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	ma "github.com/akshaykarle/go-mongodbatlas/mongodbatlas"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMongodbatlasProjectIPAccessList_basic(t *testing.T) {
+	projectName := "test"
+
+	resourceName := "mongodbatlas_project_ip_access_list.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMongodbatlasProjectIPAccessListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongodbatlasProjectIPAccessList(projectName, false, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasProjectIPAccessListEntries(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+				),
+			},
+			{
+				Config: testAccMongodbatlasProjectIPAccessList(projectName, false, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasProjectIPAccessListEntries(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+				),
+			},
+			{
+				PreConfig: func() { testAccMongodbatlasProjectIPAccessListAddEntry(t, projectName, "192.168.0.0/24") },
+				Config:    testAccMongodbatlasProjectIPAccessList(projectName, false, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasProjectIPAccessListEntries(resourceName, 3),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+				),
+			},
+			{
+				// Making the list authoritative removes the entry added outside of Terraform
+				Config: testAccMongodbatlasProjectIPAccessList(projectName, true, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMongodbatlasProjectIPAccessListEntries(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "authoritative", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestMongodbatlasProjectIPAccessList_read(t *testing.T) {
+	r := resourceProjectIPAccessList()
+	configured := map[string]interface{}{
+		"group": "5ba8c5c396e8211ae8272486",
+		"entry": []interface{}{
+			map[string]interface{}{"ip_address": "10.10.10.10", "comment": "ip"},
+			map[string]interface{}{"cidr_block": "10.0.0.0/21", "comment": "cidr"},
+		},
+	}
+	meta := testOfflineClient(http.StatusOK, `{"results":[
+		{"cidrBlock":"10.10.10.10/32","ipAddress":"10.10.10.10","comment":"ip","groupId":"5ba8c5c396e8211ae8272486"},
+		{"cidrBlock":"10.0.0.0/21","comment":"changed in the UI","groupId":"5ba8c5c396e8211ae8272486"},
+		{"cidrBlock":"192.168.0.0/24","comment":"added in the UI","groupId":"5ba8c5c396e8211ae8272486"}
+	],"totalCount":3}`)
+
+	for _, authoritative := range []bool{false, true} {
+		configured["authoritative"] = authoritative
+		d := schema.TestResourceDataRaw(t, r.Schema, configured)
+		d.SetId("5ba8c5c396e8211ae8272486")
+		if err := r.Read(d, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		entries := map[string]string{}
+		for _, e := range d.Get("entry").(*schema.Set).List() {
+			entry := e.(map[string]interface{})
+			entries[entry["cidr_block"].(string)+entry["ip_address"].(string)] = entry["comment"].(string)
+		}
+
+		expected := map[string]string{
+			"10.10.10.10": "ip",
+			"10.0.0.0/21": "changed in the UI",
+		}
+		if authoritative {
+			expected["192.168.0.0/24"] = "added in the UI"
+		}
+		if fmt.Sprint(entries) != fmt.Sprint(expected) {
+			t.Errorf("authoritative %t: expected entries %v, got %v", authoritative, expected, entries)
+		}
+	}
+}
+
+func TestMongodbatlasProjectIPAccessList_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceProjectIPAccessList(), map[string]interface{}{
+		"group": "5ba8c5c396e8211ae8272486",
+	}, "5ba8c5c396e8211ae8272486")
+}
+
+func TestMongodbatlasProjectIPAccessList_validateEntries(t *testing.T) {
+	cases := []struct {
+		entry ma.Whitelist
+		valid bool
+	}{
+		{ma.Whitelist{CidrBlock: "10.0.0.0/21"}, true},
+		{ma.Whitelist{IPAddress: "10.10.10.10", Comment: "ip"}, true},
+		{ma.Whitelist{AwsSecurityGroup: "sg-0123456789abcdef0"}, true},
+		{ma.Whitelist{CidrBlock: "10.0.0.0/21", IPAddress: "10.10.10.10"}, false},
+		{ma.Whitelist{Comment: "nothing"}, false},
+	}
+
+	for _, c := range cases {
+		if err := validateAccessListEntries([]ma.Whitelist{c.entry}); (err == nil) != c.valid {
+			t.Errorf("%#v: expected valid to be %t, got error %v", c.entry, c.valid, err)
+		}
+	}
+}
+
+func TestMongodbatlasProjectIPAccessList_findEntry(t *testing.T) {
+	entries := []ma.Whitelist{
+		{IPAddress: "10.10.10.10"},
+		{CidrBlock: "10.0.0.0/21"},
+		{AwsSecurityGroup: "sg-0123456789abcdef0"},
+	}
+
+	cases := []struct {
+		whitelist ma.Whitelist
+		found     bool
+	}{
+		{ma.Whitelist{CidrBlock: "10.10.10.10/32", IPAddress: "10.10.10.10"}, true},
+		{ma.Whitelist{CidrBlock: "10.10.10.10/32"}, true},
+		{ma.Whitelist{CidrBlock: "10.0.0.0/21"}, true},
+		{ma.Whitelist{AwsSecurityGroup: "sg-0123456789abcdef0"}, true},
+		{ma.Whitelist{CidrBlock: "10.0.0.0/16"}, false},
+		{ma.Whitelist{IPAddress: "10.10.10.11"}, false},
+		{ma.Whitelist{AwsSecurityGroup: "sg-1"}, false},
+	}
+
+	for _, c := range cases {
+		if found := findAccessListEntry(entries, c.whitelist) != nil; found != c.found {
+			t.Errorf("%#v: expected found to be %t", c.whitelist, c.found)
+		}
+	}
+}
+
+func testAccCheckMongodbatlasProjectIPAccessListEntries(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*MongoDBClient).Client
+
+		whitelists, _, err := client.Whitelist.List(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if rs.Primary.Attributes["authoritative"] == "true" && len(whitelists) != count {
+			return fmt.Errorf("expected %d entries in the whitelist, got %d", count, len(whitelists))
+		}
+		if len(whitelists) < count {
+			return fmt.Errorf("expected at least %d entries in the whitelist, got %d", count, len(whitelists))
+		}
+		return nil
+	}
+}
+
+func testAccMongodbatlasProjectIPAccessListAddEntry(t *testing.T, projectName, cidrBlock string) {
+	client := testAccProvider.Meta().(*MongoDBClient).Client
+
+	p, _, err := client.Projects.GetByName(projectName)
+	if err != nil {
+		t.Fatalf("Error reading MongoDB Project %s: %s", projectName, err)
+	}
+	_, _, err = client.Whitelist.Create(p.ID, []ma.Whitelist{{CidrBlock: cidrBlock, Comment: "added outside of Terraform"}})
+	if err != nil {
+		t.Fatalf("Error creating MongoDB Project IP Whitelist entry %s: %s", cidrBlock, err)
+	}
+}
+
+func testAccCheckMongodbatlasProjectIPAccessListDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*MongoDBClient).Client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodbatlas_project_ip_access_list" {
+			continue
+		}
+
+		whitelists, _, err := client.Whitelist.List(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error listing MongoDB Project IP Whitelist: %s", err)
+		}
+		for _, w := range whitelists {
+			if w.CidrBlock == "10.0.0.0/21" || w.IPAddress == "10.10.10.10" {
+				return fmt.Errorf("Project IP Access List entry %s still exists", w.CidrBlock)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccMongodbatlasProjectIPAccessList(projectName string, authoritative bool, comment string) string {
+	return fmt.Sprintf(`resource "mongodbatlas_project_ip_access_list" "test" {
+  group = "${data.mongodbatlas_project.test.id}"
+  authoritative = %t
+
+  entry {
+    cidr_block = "10.0.0.0/21"
+    comment = "%s"
+  }
+
+  entry {
+    ip_address = "10.10.10.10"
+    comment = "%s"
+  }
+}
+
+data "mongodbatlas_project" "test" {
+  name = "%s"
+}`, authoritative, comment, comment, projectName)
+}
@@ -34,3 +34,10 @@ func testAccMongodbatlasProject(projectName string) string {
   name = "%s"
 }`, projectName)
 }
+
+func TestMongodbatlasProject_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceProject(), map[string]interface{}{
+		"org_id": "5ba8c5c396e8211ae8272485",
+		"name":   "test",
+	}, "5ba8c5c396e8211ae8272486")
+}
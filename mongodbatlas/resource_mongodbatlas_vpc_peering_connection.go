@@ -66,6 +66,31 @@ func resourceVpcPeeringConnection() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"atlas_cidr_block": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"azure_directory_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"azure_subscription_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"resource_group_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"vnet_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 			"container_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -108,7 +133,7 @@ func resourceVpcPeeringConnection() *schema.Resource {
 }
 
 func resourceVpcPeeringConnectionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	params := ma.Peer{
 		ContainerID:  d.Get("container_id").(string),
@@ -124,6 +149,13 @@ func resourceVpcPeeringConnectionCreate(d *schema.ResourceData, meta interface{}
 		params.GcpProjectID = d.Get("gcp_project_id").(string)
 		params.NetworkName = d.Get("network_name").(string)
 	}
+	if d.Get("provider_name").(string) == "AZURE" {
+		params.AtlasCidrBlock = d.Get("atlas_cidr_block").(string)
+		params.AzureDirectoryID = d.Get("azure_directory_id").(string)
+		params.AzureSubscriptionID = d.Get("azure_subscription_id").(string)
+		params.ResourceGroupName = d.Get("resource_group_name").(string)
+		params.VnetName = d.Get("vnet_name").(string)
+	}
 
 	peer, _, err := client.Peers.Create(d.Get("group").(string), &params)
 	if err != nil {
@@ -153,10 +185,15 @@ func resourceVpcPeeringConnectionCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceVpcPeeringConnectionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
-	p, _, err := client.Peers.Get(d.Get("group").(string), d.Id())
+	p, resp, err := client.Peers.Get(d.Get("group").(string), d.Id())
 	if err != nil {
+		if isNotFound(resp) {
+			log.Printf("[WARN] MongoDB Peering connection %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading MongoDB Peering connection %s: %s", d.Id(), err)
 	}
 
@@ -194,6 +231,29 @@ func resourceVpcPeeringConnectionRead(d *schema.ResourceData, meta interface{})
 			log.Printf("[WARN] Error setting error_message for (%s): %s", d.Id(), err)
 		}
 	}
+	if d.Get("provider_name").(string) == "AZURE" {
+		if err := d.Set("atlas_cidr_block", p.AtlasCidrBlock); err != nil {
+			log.Printf("[WARN] Error setting atlas_cidr_block for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("azure_directory_id", p.AzureDirectoryID); err != nil {
+			log.Printf("[WARN] Error setting azure_directory_id for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("azure_subscription_id", p.AzureSubscriptionID); err != nil {
+			log.Printf("[WARN] Error setting azure_subscription_id for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("resource_group_name", p.ResourceGroupName); err != nil {
+			log.Printf("[WARN] Error setting resource_group_name for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("vnet_name", p.VnetName); err != nil {
+			log.Printf("[WARN] Error setting vnet_name for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("status", p.Status); err != nil {
+			log.Printf("[WARN] Error setting status for (%s): %s", d.Id(), err)
+		}
+		if err := d.Set("error_message", p.ErrorState); err != nil {
+			log.Printf("[WARN] Error setting error_message for (%s): %s", d.Id(), err)
+		}
+	}
 
 	if err := d.Set("identifier", p.ID); err != nil {
 		log.Printf("[WARN] Error setting identifier for (%s): %s", d.Id(), err)
@@ -206,7 +266,7 @@ func resourceVpcPeeringConnectionRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceVpcPeeringConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	requestUpdate := false
 
 	c, _, err := client.Peers.Get(d.Get("group").(string), d.Id())
@@ -260,7 +320,7 @@ func resourceVpcPeeringConnectionUpdate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceVpcPeeringConnectionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 
 	log.Printf("[DEBUG] MongoDB VPC Peering connection destroy: %v", d.Id())
 	_, err := client.Peers.Delete(d.Get("group").(string), d.Id())
@@ -303,7 +363,7 @@ func resourceVpcPeeringConnectionImportState(d *schema.ResourceData, meta interf
 	}
 	gid := parts[0]
 	connectionID := parts[1]
-	client := meta.(*ma.Client)
+	client := meta.(*MongoDBClient).Client
 	peer, err := getConnection(client, gid, connectionID)
 	if err != nil {
 		return nil, err
@@ -319,6 +379,10 @@ func resourceVpcPeeringConnectionImportState(d *schema.ResourceData, meta interf
 		if err := d.Set("provider_name", "GCP"); err != nil {
 			return nil, fmt.Errorf("Error setting provider name: %v", err)
 		}
+	} else if peer.AzureSubscriptionID != "" {
+		if err := d.Set("provider_name", "AZURE"); err != nil {
+			return nil, fmt.Errorf("Error setting provider name: %v", err)
+		}
 	}
 
 	d.SetId(peer.ID)
@@ -344,7 +408,7 @@ func resourceVpcPeeringConnectionStateRefreshFunc(id, group string, client *ma.C
 		log.Printf("[INFO] Current status: %s", status)
 
 		if err != nil {
-			if resp.StatusCode == 404 {
+			if isNotFound(resp) {
 				return 42, "DELETED", nil
 			}
 			log.Printf("Error reading MongoDB VPC Peering connection %s: %s", id, err)
@@ -0,0 +1,15 @@
+package mongodbatlas
+
+import (
+	"testing"
+)
+
+func TestMongodbatlasVpcPeeringConnection_readNotFound(t *testing.T) {
+	testReadNotFound(t, resourceVpcPeeringConnection(), map[string]interface{}{
+		"group":                  "5ba8c5c396e8211ae8272486",
+		"provider_name":          "AWS",
+		"vpc_id":                 "vpc-0123456789abcdef0",
+		"aws_account_id":         "123456789012",
+		"route_table_cidr_block": "10.0.0.0/16",
+	}, "5ba8c5c396e8211ae8272487")
+}
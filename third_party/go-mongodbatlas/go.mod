@@ -0,0 +1,5 @@
+module github.com/akshaykarle/go-mongodbatlas
+
+go 1.12
+
+require github.com/dghubble/sling v1.2.0
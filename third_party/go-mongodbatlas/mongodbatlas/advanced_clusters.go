@@ -0,0 +1,103 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+// AdvancedClusterService provides methods for accessing MongoDB Atlas Advanced Clusters API endpoints.
+// Advanced clusters can span several cloud providers, with different hardware in each region.
+type AdvancedClusterService struct {
+	sling *sling.Sling
+}
+
+// newAdvancedClusterService returns a new AdvancedClusterService.
+func newAdvancedClusterService(sling *sling.Sling) *AdvancedClusterService {
+	return &AdvancedClusterService{
+		sling: sling.Path("groups/"),
+	}
+}
+
+// HardwareSpec is the hardware of a type of nodes in a region.
+type HardwareSpec struct {
+	InstanceSize  string `json:"instanceSize,omitempty"`
+	NodeCount     *int   `json:"nodeCount,omitempty"`
+	DiskIOPS      int    `json:"diskIOPS,omitempty"`
+	EbsVolumeType string `json:"ebsVolumeType,omitempty"`
+}
+
+// RegionConfig describes the provider, priority and nodes of a region of an advanced cluster.
+type RegionConfig struct {
+	ProviderName        string        `json:"providerName,omitempty"`
+	BackingProviderName string        `json:"backingProviderName,omitempty"`
+	RegionName          string        `json:"regionName,omitempty"`
+	Priority            *int          `json:"priority,omitempty"`
+	ElectableSpecs      *HardwareSpec `json:"electableSpecs,omitempty"`
+	ReadOnlySpecs       *HardwareSpec `json:"readOnlySpecs,omitempty"`
+	AnalyticsSpecs      *HardwareSpec `json:"analyticsSpecs,omitempty"`
+}
+
+// AdvancedReplicationSpec describes a zone of an advanced cluster and the regions it spans.
+type AdvancedReplicationSpec struct {
+	ID            string         `json:"id,omitempty"`
+	NumShards     int            `json:"numShards,omitempty"`
+	ZoneName      string         `json:"zoneName,omitempty"`
+	RegionConfigs []RegionConfig `json:"regionConfigs,omitempty"`
+}
+
+// AdvancedCluster represents an advanced Cluster configuration in MongoDB.
+type AdvancedCluster struct {
+	ID                  string                    `json:"id,omitempty"`
+	GroupID             string                    `json:"groupId,omitempty"`
+	Name                string                    `json:"name,omitempty"`
+	ClusterType         string                    `json:"clusterType,omitempty"`
+	MongoDBMajorVersion string                    `json:"mongoDBMajorVersion,omitempty"`
+	MongoDBVersion      string                    `json:"mongoDBVersion,omitempty"`
+	DiskSizeGB          float64                   `json:"diskSizeGB,omitempty"`
+	BackupEnabled       *bool                     `json:"backupEnabled,omitempty"`
+	Paused              *bool                     `json:"paused,omitempty"`
+	StateName           string                    `json:"stateName,omitempty"`
+	ReplicationSpecs    []AdvancedReplicationSpec `json:"replicationSpecs,omitempty"`
+}
+
+// Get an advanced cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/cluster-advanced/get-one-cluster-advanced/
+func (c *AdvancedClusterService) Get(gid string, name string) (*AdvancedCluster, *http.Response, error) {
+	cluster := new(AdvancedCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s", gid, name)
+	resp, err := c.sling.New().Get(path).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// Create an advanced cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/cluster-advanced/create-one-cluster-advanced/
+func (c *AdvancedClusterService) Create(gid string, clusterParams *AdvancedCluster) (*AdvancedCluster, *http.Response, error) {
+	cluster := new(AdvancedCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters", gid)
+	resp, err := c.sling.New().Post(path).BodyJSON(clusterParams).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// Update an advanced cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/cluster-advanced/modify-one-cluster-advanced/
+func (c *AdvancedClusterService) Update(gid string, name string, clusterParams *AdvancedCluster) (*AdvancedCluster, *http.Response, error) {
+	cluster := new(AdvancedCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s", gid, name)
+	resp, err := c.sling.New().Patch(path).BodyJSON(clusterParams).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// Delete an advanced cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/cluster-advanced/delete-one-cluster-advanced/
+func (c *AdvancedClusterService) Delete(gid string, name string) (*http.Response, error) {
+	cluster := new(AdvancedCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s", gid, name)
+	resp, err := c.sling.New().Delete(path).Receive(cluster, apiError)
+	return resp, relevantError(err, *apiError)
+}
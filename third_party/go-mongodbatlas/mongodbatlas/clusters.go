@@ -0,0 +1,248 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+// ClusterService provides methods for accessing MongoDB Atlas Clusters API endpoints.
+type ClusterService struct {
+	sling *sling.Sling
+}
+
+// newClusterService returns a new ClusterService.
+func newClusterService(sling *sling.Sling) *ClusterService {
+	return &ClusterService{
+		sling: sling.Path("groups/"),
+	}
+}
+
+// AutoScaling has the information on whether disk and compute auto-scaling are enabled.
+type AutoScaling struct {
+	DiskGBEnabled bool                `json:"diskGBEnabled"`
+	Compute       *ComputeAutoScaling `json:"compute,omitempty"`
+}
+
+// ComputeAutoScaling has the information on whether the cluster tier can scale up and down.
+type ComputeAutoScaling struct {
+	Enabled          bool `json:"enabled"`
+	ScaleDownEnabled bool `json:"scaleDownEnabled"`
+}
+
+// ProviderAutoScaling has the range of instance sizes the cluster can scale between.
+type ProviderAutoScaling struct {
+	Compute ComputeInstanceSizes `json:"compute"`
+}
+
+// ComputeInstanceSizes is the range of instance sizes for compute auto-scaling.
+type ComputeInstanceSizes struct {
+	MinInstanceSize string `json:"minInstanceSize,omitempty"`
+	MaxInstanceSize string `json:"maxInstanceSize,omitempty"`
+}
+
+// BiConnector has the settings of the BI Connector for Atlas of a cluster.
+type BiConnector struct {
+	Enabled        bool   `json:"enabled"`
+	ReadPreference string `json:"readPreference,omitempty"`
+}
+
+// Label is a key-value pair that tags and categorizes a cluster.
+type Label struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ReplicationSpec describes a region’s priority in elections,
+// and the number and type of MongoDB nodes Atlas deploys to the region.
+type ReplicationSpec struct {
+	Priority       int `json:"priority"`
+	ElectableNodes int `json:"electableNodes"`
+	ReadOnlyNodes  int `json:"readOnlyNodes"`
+	AnalyticsNodes int `json:"analyticsNodes"`
+}
+
+// ZoneReplicationSpec describes a zone of a cluster, with the number of shards
+// and the replication spec of each region of the zone.
+type ZoneReplicationSpec struct {
+	ID            string                     `json:"id,omitempty"`
+	NumShards     int                        `json:"numShards,omitempty"`
+	ZoneName      string                     `json:"zoneName,omitempty"`
+	RegionsConfig map[string]ReplicationSpec `json:"regionsConfig,omitempty"`
+}
+
+// ProviderSettings is the configuration for the provisioned servers on which MongoDB runs.
+// The available options are specific to the cloud service provider.
+type ProviderSettings struct {
+	ProviderName        string               `json:"providerName,omitempty"`
+	BackingProviderName string               `json:"backingProviderName,omitempty"`
+	RegionName          string               `json:"regionName,omitempty"`
+	InstanceSizeName    string               `json:"instanceSizeName,omitempty"`
+	DiskIOPS            int                  `json:"diskIOPS,omitempty"`
+	EncryptEBSVolume    *bool                `json:"encryptEBSVolume,omitempty"`
+	VolumeType          string               `json:"volumeType,omitempty"`
+	AutoScaling         *ProviderAutoScaling `json:"autoScaling,omitempty"`
+}
+
+// PrivateEndpointConnectionString is the connection string of a cluster through a private endpoint.
+type PrivateEndpointConnectionString struct {
+	ConnectionString    string            `json:"connectionString,omitempty"`
+	SRVConnectionString string            `json:"srvConnectionString,omitempty"`
+	Type                string            `json:"type,omitempty"`
+	Endpoints           []PrivateEndpoint `json:"endpoints,omitempty"`
+}
+
+// PrivateEndpoint is a private endpoint through which a cluster is reachable.
+type PrivateEndpoint struct {
+	EndpointID   string `json:"endpointId,omitempty"`
+	ProviderName string `json:"providerName,omitempty"`
+	Region       string `json:"region,omitempty"`
+}
+
+// ConnectionStrings are the URIs to connect to a cluster, publicly, through network peering or through private endpoints.
+type ConnectionStrings struct {
+	Standard          string                            `json:"standard,omitempty"`
+	StandardSrv       string                            `json:"standardSrv,omitempty"`
+	Private           string                            `json:"private,omitempty"`
+	PrivateSrv        string                            `json:"privateSrv,omitempty"`
+	AwsPrivateLink    map[string]string                 `json:"awsPrivateLink,omitempty"`
+	AwsPrivateLinkSrv map[string]string                 `json:"awsPrivateLinkSrv,omitempty"`
+	PrivateEndpoint   []PrivateEndpointConnectionString `json:"privateEndpoint,omitempty"`
+}
+
+// Cluster represents a Cluster configuration in MongoDB.
+type Cluster struct {
+	ID                    string                     `json:"id,omitempty"`
+	GroupID               string                     `json:"groupId,omitempty"`
+	Name                  string                     `json:"name,omitempty"`
+	MongoDBVersion        string                     `json:"mongoDBVersion,omitempty"`
+	MongoDBMajorVersion   string                     `json:"mongoDBMajorVersion,omitempty"`
+	MongoURI              string                     `json:"mongoURI,omitempty"`
+	MongoURIUpdated       string                     `json:"mongoURIUpdated,omitempty"`
+	MongoURIWithOptions   string                     `json:"mongoURIWithOptions,omitempty"`
+	SrvAddress            string                     `json:"srvAddress,omitempty"`
+	ConnectionStrings     *ConnectionStrings         `json:"connectionStrings,omitempty"`
+	DiskSizeGB            float64                    `json:"diskSizeGB,omitempty"`
+	BackupEnabled         *bool                      `json:"backupEnabled,omitempty"`
+	ProviderBackupEnabled *bool                      `json:"providerBackupEnabled,omitempty"`
+	StateName             string                     `json:"stateName,omitempty"`
+	ReplicationFactor     int                        `json:"replicationFactor,omitempty"`
+	ClusterType           string                     `json:"clusterType,omitempty"`
+	ReplicationSpec       map[string]ReplicationSpec `json:"replicationSpec,omitempty"`
+	ReplicationSpecs      []ZoneReplicationSpec      `json:"replicationSpecs,omitempty"`
+	NumShards             int                        `json:"numShards,omitempty"`
+	Paused                bool                       `json:"paused"`
+	TerminationProtection *bool                      `json:"terminationProtectionEnabled,omitempty"`
+	AutoScaling           *AutoScaling               `json:"autoScaling,omitempty"`
+	ProviderSettings      ProviderSettings           `json:"providerSettings,omitempty"`
+	BiConnector           *BiConnector               `json:"biConnector,omitempty"`
+	Labels                []Label                    `json:"labels"`
+}
+
+// clusterListResponse is the response from the ClusterService.List.
+type clusterListResponse struct {
+	Results    []Cluster `json:"results"`
+	TotalCount int       `json:"totalCount"`
+}
+
+// List all clusters for the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-get-all/
+func (c *ClusterService) List(gid string) ([]Cluster, *http.Response, error) {
+	response := new(clusterListResponse)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters", gid)
+	resp, err := c.sling.New().Get(path).Receive(response, apiError)
+	return response.Results, resp, relevantError(err, *apiError)
+}
+
+// Get a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-get-one/
+func (c *ClusterService) Get(gid string, name string) (*Cluster, *http.Response, error) {
+	cluster := new(Cluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s", gid, name)
+	resp, err := c.sling.New().Get(path).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// Create a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-create-one/
+func (c *ClusterService) Create(gid string, clusterParams *Cluster) (*Cluster, *http.Response, error) {
+	cluster := new(Cluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters", gid)
+	resp, err := c.sling.New().Post(path).BodyJSON(clusterParams).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// Update a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-modify-one/
+func (c *ClusterService) Update(gid string, name string, clusterParams *Cluster) (*Cluster, *http.Response, error) {
+	cluster := new(Cluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s", gid, name)
+	resp, err := c.sling.New().Patch(path).BodyJSON(clusterParams).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// UpgradeTenant upgrades a shared-tier (M2/M5) cluster in the specified group to a dedicated cluster.
+// https://docs.atlas.mongodb.com/reference/api/clusters-modify-one/
+func (c *ClusterService) UpgradeTenant(gid string, clusterParams *Cluster) (*Cluster, *http.Response, error) {
+	cluster := new(Cluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/tenantUpgrade", gid)
+	resp, err := c.sling.New().Post(path).BodyJSON(clusterParams).Receive(cluster, apiError)
+	return cluster, resp, relevantError(err, *apiError)
+}
+
+// RestartPrimaries triggers a failover of the primaries of a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-test-failover/
+func (c *ClusterService) RestartPrimaries(gid string, name string) (*http.Response, error) {
+	cluster := new(Cluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/restartPrimaries", gid, name)
+	resp, err := c.sling.New().Post(path).Receive(cluster, apiError)
+	return resp, relevantError(err, *apiError)
+}
+
+// Delete a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-delete-one/
+func (c *ClusterService) Delete(gid string, name string) (*http.Response, error) {
+	cluster := new(Cluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s", gid, name)
+	resp, err := c.sling.New().Delete(path).Receive(cluster, apiError)
+	return resp, relevantError(err, *apiError)
+}
+
+// ProcessArgs represents the advanced configuration options of the mongod processes of a cluster.
+// Pointers distinguish options which are not set from their zero values.
+type ProcessArgs struct {
+	FailIndexKeyTooLong       *bool  `json:"failIndexKeyTooLong,omitempty"`
+	JavascriptEnabled         *bool  `json:"javascriptEnabled,omitempty"`
+	MinimumEnabledTLSProtocol string `json:"minimumEnabledTlsProtocol,omitempty"`
+	NoTableScan               *bool  `json:"noTableScan,omitempty"`
+	OplogSizeMB               *int   `json:"oplogSizeMB,omitempty"`
+	SampleSizeBIConnector     *int   `json:"sampleSizeBIConnector,omitempty"`
+}
+
+// GetProcessArgs gets the advanced configuration options of a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-get-advanced-configuration-options/
+func (c *ClusterService) GetProcessArgs(gid string, name string) (*ProcessArgs, *http.Response, error) {
+	processArgs := new(ProcessArgs)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/processArgs", gid, name)
+	resp, err := c.sling.New().Get(path).Receive(processArgs, apiError)
+	return processArgs, resp, relevantError(err, *apiError)
+}
+
+// UpdateProcessArgs updates the advanced configuration options of a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/clusters-modify-advanced-configuration-options/
+func (c *ClusterService) UpdateProcessArgs(gid string, name string, processArgsParams *ProcessArgs) (*ProcessArgs, *http.Response, error) {
+	processArgs := new(ProcessArgs)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/processArgs", gid, name)
+	resp, err := c.sling.New().Patch(path).BodyJSON(processArgsParams).Receive(processArgs, apiError)
+	return processArgs, resp, relevantError(err, *apiError)
+}
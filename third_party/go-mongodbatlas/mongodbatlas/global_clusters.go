@@ -0,0 +1,110 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+// GlobalClusterService provides methods for accessing MongoDB Atlas Global Clusters API endpoints.
+type GlobalClusterService struct {
+	sling *sling.Sling
+}
+
+// newGlobalClusterService returns a new GlobalClusterService.
+func newGlobalClusterService(sling *sling.Sling) *GlobalClusterService {
+	return &GlobalClusterService{
+		sling: sling.Path("groups/"),
+	}
+}
+
+// ManagedNamespace represents a sharded collection of a Global Cluster.
+type ManagedNamespace struct {
+	Db                     string `json:"db,omitempty"`
+	Collection             string `json:"collection,omitempty"`
+	CustomShardKey         string `json:"customShardKey,omitempty"`
+	IsCustomShardKeyHashed bool   `json:"isCustomShardKeyHashed,omitempty"`
+	IsShardKeyUnique       bool   `json:"isShardKeyUnique,omitempty"`
+}
+
+// CustomZoneMapping maps a location code to a zone of a Global Cluster.
+type CustomZoneMapping struct {
+	Location string `json:"location,omitempty"`
+	Zone     string `json:"zone,omitempty"`
+}
+
+// customZoneMappingsRequest is the request body of the GlobalClusterService.AddCustomZoneMappings.
+type customZoneMappingsRequest struct {
+	CustomZoneMappings []CustomZoneMapping `json:"customZoneMappings"`
+}
+
+// GlobalCluster represents the managed namespaces and custom zone mappings of a Global Cluster.
+// CustomZoneMapping maps each location code to the ID of its zone.
+type GlobalCluster struct {
+	CustomZoneMapping map[string]string  `json:"customZoneMapping,omitempty"`
+	ManagedNamespaces []ManagedNamespace `json:"managedNamespaces,omitempty"`
+}
+
+// Get the managed namespaces and custom zone mappings of a Global Cluster.
+// https://docs.atlas.mongodb.com/reference/api/global-clusters-retrieve-namespaces/
+func (c *GlobalClusterService) Get(gid string, clusterName string) (*GlobalCluster, *http.Response, error) {
+	globalCluster := new(GlobalCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/globalWrites", gid, clusterName)
+	resp, err := c.sling.New().Get(path).Receive(globalCluster, apiError)
+	return globalCluster, resp, relevantError(err, *apiError)
+}
+
+// AddManagedNamespace adds a managed namespace to a Global Cluster.
+// https://docs.atlas.mongodb.com/reference/api/global-clusters-add-namespace/
+func (c *GlobalClusterService) AddManagedNamespace(gid string, clusterName string, namespaceParams *ManagedNamespace) (*GlobalCluster, *http.Response, error) {
+	globalCluster := new(GlobalCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/globalWrites/managedNamespaces", gid, clusterName)
+	resp, err := c.sling.New().Post(path).BodyJSON(namespaceParams).Receive(globalCluster, apiError)
+	return globalCluster, resp, relevantError(err, *apiError)
+}
+
+// managedNamespaceQuery identifies the managed namespace to delete.
+type managedNamespaceQuery struct {
+	Db         string `url:"db"`
+	Collection string `url:"collection"`
+}
+
+// DeleteManagedNamespace removes a managed namespace from a Global Cluster.
+// https://docs.atlas.mongodb.com/reference/api/global-clusters-delete-namespace/
+func (c *GlobalClusterService) DeleteManagedNamespace(gid string, clusterName string, namespace *ManagedNamespace) (*GlobalCluster, *http.Response, error) {
+	globalCluster := new(GlobalCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/globalWrites/managedNamespaces", gid, clusterName)
+	query := &managedNamespaceQuery{
+		Db:         namespace.Db,
+		Collection: namespace.Collection,
+	}
+	resp, err := c.sling.New().Delete(path).QueryStruct(query).Receive(globalCluster, apiError)
+	return globalCluster, resp, relevantError(err, *apiError)
+}
+
+// AddCustomZoneMappings adds entries to the custom zone mapping of a Global Cluster.
+// https://docs.atlas.mongodb.com/reference/api/global-clusters-add-customzonemapping/
+func (c *GlobalClusterService) AddCustomZoneMappings(gid string, clusterName string, mappings []CustomZoneMapping) (*GlobalCluster, *http.Response, error) {
+	globalCluster := new(GlobalCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/globalWrites/customZoneMapping", gid, clusterName)
+	params := customZoneMappingsRequest{
+		CustomZoneMappings: mappings,
+	}
+	resp, err := c.sling.New().Post(path).BodyJSON(params).Receive(globalCluster, apiError)
+	return globalCluster, resp, relevantError(err, *apiError)
+}
+
+// DeleteCustomZoneMappings removes all entries from the custom zone mapping of a Global Cluster.
+// https://docs.atlas.mongodb.com/reference/api/global-clusters-delete-customzonemappings/
+func (c *GlobalClusterService) DeleteCustomZoneMappings(gid string, clusterName string) (*GlobalCluster, *http.Response, error) {
+	globalCluster := new(GlobalCluster)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/globalWrites/customZoneMapping", gid, clusterName)
+	resp, err := c.sling.New().Delete(path).Receive(globalCluster, apiError)
+	return globalCluster, resp, relevantError(err, *apiError)
+}
@@ -0,0 +1,57 @@
+package mongodbatlas
+
+import (
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+const apiURL = "https://cloud.mongodb.com/api/atlas/v1.0/"
+
+// advancedAPIURL is the base of the API version which supports advanced clusters.
+const advancedAPIURL = "https://cloud.mongodb.com/api/atlas/v1.5/"
+
+// Client is a MongoDB Atlas client for making MongoDB API requests.
+type Client struct {
+	sling               *sling.Sling
+	Root                *RootService
+	Whitelist           *WhitelistService
+	Projects            *ProjectService
+	Clusters            *ClusterService
+	Containers          *ContainerService
+	Peers               *PeerService
+	DatabaseUsers       *DatabaseUserService
+	Organizations       *OrganizationService
+	AlertConfigurations *AlertConfigurationService
+	SnapshotSchedule    *SnapshotScheduleService
+	AtlasUsers          *AtlasUserService
+	PrivateIPMode       *PrivateIPModeService
+	GlobalClusters      *GlobalClusterService
+	AdvancedClusters    *AdvancedClusterService
+	OutageSimulations   *OutageSimulationService
+}
+
+// NewClient returns a new Client.
+func NewClient(httpClient *http.Client) *Client {
+	base := sling.New().Client(httpClient).Base(apiURL)
+	advancedBase := sling.New().Client(httpClient).Base(advancedAPIURL)
+
+	return &Client{
+		sling:               base,
+		Root:                newRootService(base.New()),
+		Whitelist:           newWhitelistService(base.New()),
+		Projects:            newProjectService(base.New()),
+		Clusters:            newClusterService(base.New()),
+		Containers:          newContainerService(base.New()),
+		Peers:               newPeerService(base.New()),
+		DatabaseUsers:       newDatabaseUserService(base.New()),
+		Organizations:       newOrganizationService(base.New()),
+		AlertConfigurations: newAlertConfigurationService(base.New()),
+		SnapshotSchedule:    newSnapshotScheduleService(base.New()),
+		AtlasUsers:          newAtlasUserService(base.New()),
+		PrivateIPMode:       newPrivateIPModeService(base.New()),
+		GlobalClusters:      newGlobalClusterService(base.New()),
+		AdvancedClusters:    newAdvancedClusterService(advancedBase.New()),
+		OutageSimulations:   newOutageSimulationService(base.New()),
+	}
+}
@@ -0,0 +1,67 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+// OutageSimulationService provides methods for accessing MongoDB Atlas Cluster Outage Simulation API endpoints.
+type OutageSimulationService struct {
+	sling *sling.Sling
+}
+
+// newOutageSimulationService returns a new OutageSimulationService.
+func newOutageSimulationService(sling *sling.Sling) *OutageSimulationService {
+	return &OutageSimulationService{
+		sling: sling.Path("groups/"),
+	}
+}
+
+// OutageFilter describes a region whose outage is simulated.
+type OutageFilter struct {
+	CloudProvider string `json:"cloudProvider,omitempty"`
+	RegionName    string `json:"regionName,omitempty"`
+	Type          string `json:"type,omitempty"`
+}
+
+// OutageSimulation represents an outage simulation of a Cluster in MongoDB.
+type OutageSimulation struct {
+	ID               string         `json:"id,omitempty"`
+	GroupID          string         `json:"groupId,omitempty"`
+	ClusterName      string         `json:"clusterName,omitempty"`
+	OutageFilters    []OutageFilter `json:"outageFilters,omitempty"`
+	StartRequestDate string         `json:"startRequestDate,omitempty"`
+	State            string         `json:"state,omitempty"`
+}
+
+// Get the outage simulation of a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/get-cluster-outage-simulation/
+func (c *OutageSimulationService) Get(gid string, clusterName string) (*OutageSimulation, *http.Response, error) {
+	simulation := new(OutageSimulation)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/outageSimulation", gid, clusterName)
+	resp, err := c.sling.New().Get(path).Receive(simulation, apiError)
+	return simulation, resp, relevantError(err, *apiError)
+}
+
+// Start an outage simulation of a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/start-cluster-outage-simulation/
+func (c *OutageSimulationService) Start(gid string, clusterName string, simulationParams *OutageSimulation) (*OutageSimulation, *http.Response, error) {
+	simulation := new(OutageSimulation)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/outageSimulation", gid, clusterName)
+	resp, err := c.sling.New().Post(path).BodyJSON(simulationParams).Receive(simulation, apiError)
+	return simulation, resp, relevantError(err, *apiError)
+}
+
+// End the outage simulation of a cluster in the specified group.
+// https://docs.atlas.mongodb.com/reference/api/end-cluster-outage-simulation/
+func (c *OutageSimulationService) End(gid string, clusterName string) (*OutageSimulation, *http.Response, error) {
+	simulation := new(OutageSimulation)
+	apiError := new(APIError)
+	path := fmt.Sprintf("%s/clusters/%s/outageSimulation", gid, clusterName)
+	resp, err := c.sling.New().Delete(path).Receive(simulation, apiError)
+	return simulation, resp, relevantError(err, *apiError)
+}